@@ -0,0 +1,120 @@
+package fetch
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// AbortError is the error a Fetch delivers when its AbortSignal is aborted
+// before or during the request, analogous to the JS API's AbortError
+// DOMException.
+//
+// https://fetch.spec.whatwg.org/#concept-request-done-flag
+type AbortError struct {
+	// Reason is the value passed to AbortController.Abort, or
+	// context.Canceled if none was given.
+	Reason error
+}
+
+func (e *AbortError) Error() string {
+	return fmt.Sprintf("fetch: aborted: %v", e.Reason)
+}
+
+func (e *AbortError) Unwrap() error {
+	return e.Reason
+}
+
+// AbortSignal observes whether the AbortController that owns it has fired
+// Abort, for passing to RequestInit.Signal so a caller can cancel a Fetch
+// in flight.
+//
+// https://fetch.spec.whatwg.org/#requestinit
+type AbortSignal struct {
+	ctx context.Context
+
+	mu        sync.Mutex
+	reason    error
+	listeners []func(reason error)
+}
+
+// Context returns the context.Context that is cancelled when the signal is
+// aborted, for Client implementations to propagate to outgoing requests.
+func (s *AbortSignal) Context() context.Context {
+	return s.ctx
+}
+
+// Aborted reports whether Abort has been called.
+func (s *AbortSignal) Aborted() bool {
+	return s.ctx.Err() != nil
+}
+
+// Reason returns the value passed to Abort, or nil if the signal has not
+// been aborted.
+func (s *AbortSignal) Reason() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.reason
+}
+
+// AddEventListener registers fn to run when the signal is aborted, mirroring
+// the JS API's signal.addEventListener("abort", fn). If the signal is
+// already aborted, fn runs immediately.
+func (s *AbortSignal) AddEventListener(fn func(reason error)) {
+	s.mu.Lock()
+	if s.reason != nil {
+		reason := s.reason
+		s.mu.Unlock()
+		fn(reason)
+		return
+	}
+	s.listeners = append(s.listeners, fn)
+	s.mu.Unlock()
+}
+
+// AbortController lets a caller cancel a Fetch in flight, analogous to the
+// JS API's AbortController.
+//
+// https://dom.spec.whatwg.org/#interface-abortcontroller
+type AbortController struct {
+	signal *AbortSignal
+	cancel context.CancelFunc
+}
+
+// NewAbortController returns a new AbortController whose Signal has not
+// been aborted.
+func NewAbortController() *AbortController {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &AbortController{
+		signal: &AbortSignal{ctx: ctx},
+		cancel: cancel,
+	}
+}
+
+// Signal returns the AbortSignal to pass as RequestInit.Signal.
+func (c *AbortController) Signal() *AbortSignal {
+	return c.signal
+}
+
+// Abort cancels c's Signal, recording reason (or context.Canceled, if nil)
+// as its Reason and running any listeners registered via
+// Signal().AddEventListener.
+func (c *AbortController) Abort(reason error) {
+	if reason == nil {
+		reason = context.Canceled
+	}
+
+	c.signal.mu.Lock()
+	if c.signal.reason != nil {
+		c.signal.mu.Unlock()
+		return
+	}
+	c.signal.reason = reason
+	listeners := c.signal.listeners
+	c.signal.mu.Unlock()
+
+	c.cancel()
+	for _, fn := range listeners {
+		fn(reason)
+	}
+}
@@ -0,0 +1,145 @@
+// Package auth resolves Basic auth credentials for outgoing requests,
+// following the same approach cmd/go's internal web client takes for
+// .netrc: a pluggable CredentialStore interface with a default
+// implementation backed by a netrc file, so library users can swap in a
+// Keychain, Secret Service, or Vault-backed store without touching global
+// state.
+package auth
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// CredentialStore resolves Basic auth credentials for a URL.
+type CredentialStore interface {
+	// Lookup returns the username and password to use for u, and whether
+	// the store has an entry for its host.
+	Lookup(u *url.URL) (user, pass string, ok bool)
+}
+
+// NetrcStore is a CredentialStore backed by a netrc file.
+type NetrcStore struct {
+	machines   map[string][2]string
+	hasDefault bool
+	def        [2]string
+}
+
+// NewNetrcStore parses the netrc file at path into a NetrcStore. If path
+// is empty, it uses $NETRC if set, else $HOME/.netrc (%USERPROFILE%\_netrc
+// on Windows), matching cmd/go's web client. A missing file is not an
+// error; it yields a NetrcStore with no entries. On Unix, the file must
+// not be readable by group or other.
+func NewNetrcStore(path string) (*NetrcStore, error) {
+	if path == "" {
+		path = netrcPath()
+	}
+	if path == "" {
+		return &NetrcStore{machines: map[string][2]string{}}, nil
+	}
+
+	if runtime.GOOS != "windows" {
+		info, err := os.Stat(path)
+		if err != nil {
+			if os.IsNotExist(err) {
+				return &NetrcStore{machines: map[string][2]string{}}, nil
+			}
+			return nil, err
+		}
+		if info.Mode().Perm()&0077 != 0 {
+			return nil, fmt.Errorf("auth: %s must not be readable by group or other (mode %04o)", path, info.Mode().Perm())
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &NetrcStore{machines: map[string][2]string{}}, nil
+		}
+		return nil, err
+	}
+
+	return parseNetrc(string(data)), nil
+}
+
+func netrcPath() string {
+	if p := os.Getenv("NETRC"); p != "" {
+		return p
+	}
+	home, err := os.UserHomeDir()
+	if err != nil || home == "" {
+		return ""
+	}
+	name := ".netrc"
+	if runtime.GOOS == "windows" {
+		name = "_netrc"
+	}
+	return filepath.Join(home, name)
+}
+
+// parseNetrc implements the subset of the netrc(5) grammar cmd/go's web
+// client relies on: "machine"/"default" entries with "login" and
+// "password" tokens, whitespace-separated. "macdef" and "account" entries
+// are not supported.
+func parseNetrc(data string) *NetrcStore {
+	store := &NetrcStore{machines: map[string][2]string{}}
+
+	var machine, login, password string
+	var inDefault bool
+	flush := func() {
+		if login == "" {
+			return
+		}
+		if inDefault {
+			store.hasDefault = true
+			store.def = [2]string{login, password}
+		} else if machine != "" {
+			store.machines[machine] = [2]string{login, password}
+		}
+	}
+
+	fields := strings.Fields(data)
+	for i := 0; i < len(fields); i++ {
+		switch fields[i] {
+		case "machine":
+			flush()
+			machine, login, password, inDefault = "", "", "", false
+			if i+1 < len(fields) {
+				i++
+				machine = fields[i]
+			}
+		case "default":
+			flush()
+			machine, login, password, inDefault = "", "", "", true
+		case "login":
+			if i+1 < len(fields) {
+				i++
+				login = fields[i]
+			}
+		case "password":
+			if i+1 < len(fields) {
+				i++
+				password = fields[i]
+			}
+		}
+	}
+	flush()
+
+	return store
+}
+
+// Lookup returns the login/password for u.Hostname(), falling back to the
+// netrc "default" entry if there is no machine-specific one.
+func (s *NetrcStore) Lookup(u *url.URL) (user, pass string, ok bool) {
+	if creds, found := s.machines[u.Hostname()]; found {
+		return creds[0], creds[1], true
+	}
+	if s.hasDefault {
+		return s.def[0], s.def[1], true
+	}
+	return "", "", false
+}
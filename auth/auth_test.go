@@ -0,0 +1,49 @@
+package auth
+
+import (
+	"net/url"
+	"testing"
+)
+
+func mustURL(t *testing.T, raw string) *url.URL {
+	t.Helper()
+	u, err := url.Parse(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return u
+}
+
+func TestParseNetrcMachine(t *testing.T) {
+	store := parseNetrc(`
+machine api.example.com
+	login alice
+	password hunter2
+`)
+
+	user, pass, ok := store.Lookup(mustURL(t, "https://api.example.com/v1"))
+	if !ok || user != "alice" || pass != "hunter2" {
+		t.Errorf("Lookup = %q, %q, %v; want alice, hunter2, true", user, pass, ok)
+	}
+
+	if _, _, ok := store.Lookup(mustURL(t, "https://other.example.com")); ok {
+		t.Error("Lookup for unrelated host returned ok = true")
+	}
+}
+
+func TestParseNetrcDefault(t *testing.T) {
+	store := parseNetrc(`
+machine api.example.com login alice password hunter2
+default login anon password anon@example.com
+`)
+
+	user, _, ok := store.Lookup(mustURL(t, "https://api.example.com"))
+	if !ok || user != "alice" {
+		t.Errorf("machine Lookup = %q, %v; want alice, true", user, ok)
+	}
+
+	user, _, ok = store.Lookup(mustURL(t, "https://unknown.example.com"))
+	if !ok || user != "anon" {
+		t.Errorf("default Lookup = %q, %v; want anon, true", user, ok)
+	}
+}
@@ -0,0 +1,151 @@
+package fetch
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime/multipart"
+)
+
+// ErrBodyUsed is delivered by Body's consumer methods when the body has
+// already been consumed by an earlier call to Bytes, Text, JSON, or Blob.
+var ErrBodyUsed = errors.New("fetch: body already used")
+
+// Result carries a value alongside an error, the payload delivered on
+// Body's async accessor channels — analogous to how Fetch itself delivers
+// a FetchResult.
+type Result[T any] struct {
+	Value T
+	Err   error
+}
+
+// Body is a readable request/response body, analogous to the body mixin
+// implemented by the JS API's Request and Response via a ReadableStream.
+//
+// https://fetch.spec.whatwg.org/#body-mixin
+type Body struct {
+	stream *ReadableStream
+	used   bool
+}
+
+func newBody(reader io.ReadCloser) *Body {
+	if reader == nil {
+		reader = io.NopCloser(bytes.NewReader(nil))
+	}
+	return &Body{stream: newReadableStream(reader)}
+}
+
+// NewBody wraps an already-available byte slice as a Body, for Client
+// implementations outside this package that assemble a Response body
+// directly (e.g. a headless-browser client returning rendered HTML)
+// instead of streaming an io.ReadCloser.
+func NewBody(data []byte) *Body {
+	return newBody(io.NopCloser(bytes.NewReader(data)))
+}
+
+// Stream returns the body's underlying ReadableStream, for reading it
+// incrementally or Tee-ing it instead of buffering it whole via Bytes,
+// Text, JSON, or Blob.
+func (b *Body) Stream() *ReadableStream {
+	return b.stream
+}
+
+// Bytes reads the entire body into memory on a background goroutine,
+// delivering the result on the returned channel. It may only be called
+// once across Bytes, Text, JSON, and Blob; later calls deliver
+// ErrBodyUsed.
+//
+// https://fetch.spec.whatwg.org/#dom-body-bytes
+func (b *Body) Bytes() <-chan Result[[]byte] {
+	c := make(chan Result[[]byte], 1)
+	if b.used {
+		c <- Result[[]byte]{Err: ErrBodyUsed}
+		close(c)
+		return c
+	}
+	b.used = true
+	go func() {
+		defer close(c)
+		defer b.stream.reader.Close()
+		data, err := io.ReadAll(b.stream.reader)
+		c <- Result[[]byte]{Value: data, Err: err}
+	}()
+	return c
+}
+
+// Text reads the entire body and decodes it as UTF-8.
+//
+// https://fetch.spec.whatwg.org/#dom-body-text
+func (b *Body) Text() <-chan Result[string] {
+	c := make(chan Result[string], 1)
+	go func() {
+		defer close(c)
+		bytesResult := <-b.Bytes()
+		c <- Result[string]{Value: string(bytesResult.Value), Err: bytesResult.Err}
+	}()
+	return c
+}
+
+// JSON reads the entire body and decodes it as JSON into v.
+//
+// https://fetch.spec.whatwg.org/#dom-body-json
+func (b *Body) JSON(v any) <-chan error {
+	c := make(chan error, 1)
+	go func() {
+		defer close(c)
+		bytesResult := <-b.Bytes()
+		if bytesResult.Err != nil {
+			c <- bytesResult.Err
+			return
+		}
+		c <- json.Unmarshal(bytesResult.Value, v)
+	}()
+	return c
+}
+
+// Blob reads the entire body into memory. go-fetch has no distinct Blob
+// type, so this is equivalent to Bytes; it exists for parity with the JS
+// API's Response.blob().
+//
+// https://fetch.spec.whatwg.org/#dom-body-blob
+func (b *Body) Blob() <-chan Result[[]byte] {
+	return b.Bytes()
+}
+
+// Close releases the underlying stream without reading it.
+func (b *Body) Close() error {
+	return b.stream.reader.Close()
+}
+
+// FormData is a minimal analogue of the JS API's FormData, for building
+// multipart/form-data request bodies.
+//
+// https://xhr.spec.whatwg.org/#interface-formdata
+type FormData struct {
+	fields [][2]string
+}
+
+// NewFormData returns an empty FormData.
+func NewFormData() *FormData {
+	return &FormData{}
+}
+
+// Append adds a name/value field to the form.
+func (f *FormData) Append(name string, value string) {
+	f.fields = append(f.fields, [2]string{name, value})
+}
+
+func (f *FormData) reader() (io.Reader, string, error) {
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+	for _, field := range f.fields {
+		if err := w.WriteField(field[0], field[1]); err != nil {
+			return nil, "", err
+		}
+	}
+	if err := w.Close(); err != nil {
+		return nil, "", err
+	}
+	return &buf, w.FormDataContentType(), nil
+}
@@ -0,0 +1,63 @@
+package fetch
+
+import (
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestBodyJSON(t *testing.T) {
+	body := NewBody([]byte(`{"hello":"world"}`))
+	var v struct {
+		Hello string `json:"hello"`
+	}
+	if err := <-body.JSON(&v); err != nil {
+		t.Fatalf("JSON: %v", err)
+	}
+	if v.Hello != "world" {
+		t.Errorf("Hello = %q, want %q", v.Hello, "world")
+	}
+}
+
+func TestBodyUsedTwice(t *testing.T) {
+	body := NewBody([]byte("hello"))
+	if result := <-body.Text(); result.Err != nil {
+		t.Fatalf("Text: %v", result.Err)
+	}
+	result := <-body.Bytes()
+	if !errors.Is(result.Err, ErrBodyUsed) {
+		t.Errorf("second read error = %v, want ErrBodyUsed", result.Err)
+	}
+}
+
+func TestStreamTee(t *testing.T) {
+	body := NewBody([]byte("hello world"))
+	a, b := body.Stream().Tee(0)
+
+	aBytes, err := readAllStream(a)
+	if err != nil {
+		t.Fatalf("reading branch a: %v", err)
+	}
+	bBytes, err := readAllStream(b)
+	if err != nil {
+		t.Fatalf("reading branch b: %v", err)
+	}
+	if string(aBytes) != "hello world" || string(bBytes) != "hello world" {
+		t.Errorf("branches = %q, %q, want both %q", aBytes, bBytes, "hello world")
+	}
+}
+
+func readAllStream(s *ReadableStream) ([]byte, error) {
+	var out []byte
+	buf := make([]byte, 4)
+	for {
+		result := <-s.Read(buf)
+		out = append(out, buf[:result.Value]...)
+		if result.Err == io.EOF {
+			return out, nil
+		}
+		if result.Err != nil {
+			return out, result.Err
+		}
+	}
+}
@@ -0,0 +1,294 @@
+// Package dataurl implements the data: URL scheme (RFC 2397):
+//
+//	data:[<mediatype>][;base64],<data>
+//
+// It reuses this module's Structured Fields primitives from rfc8941 for
+// the parts of the grammar that overlap: the mediatype's type/subtype is
+// validated with the same tchar/"/" predicate as rfc8941.ParseToken, and
+// parameter values reuse rfc8941's sf-token and sf-string parsers. The
+// ";base64" payload is decoded through the same lenient base64 path as
+// rfc8941.ParseBinary: missing "=" padding is tolerated, but characters
+// outside the base64 alphabet and embedded line feeds are rejected.
+package dataurl
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jcbhmr/go-fetch/rfc7230"
+	"github.com/jcbhmr/go-fetch/rfc8941"
+)
+
+// Decode parses url as a data: URL, returning its media type (e.g.
+// "image/png"), any ";attribute=value" parameters (excluding the
+// ";base64" marker, which is reflected only in how data was decoded),
+// and the decoded payload.
+func Decode(url string) (mediatype string, params map[string]string, data []byte, err error) {
+	var buf bytes.Buffer
+	mediatype, params, err = DecodeStream(strings.NewReader(url), &buf)
+	if err != nil {
+		return "", nil, nil, err
+	}
+	return mediatype, params, buf.Bytes(), nil
+}
+
+// DecodeStream is Decode, but it reads url from r and writes the
+// decoded payload to w as it is parsed, instead of allocating a
+// []byte holding the whole payload. This is the preferred entry point
+// for large blobs (e.g. embedded images or fonts).
+func DecodeStream(r io.Reader, w io.Writer) (mediatype string, params map[string]string, err error) {
+	br := bufio.NewReader(r)
+
+	// The header (everything up to the first unescaped ",") is always
+	// small relative to the data it describes, so it is read in full
+	// and parsed with the *string-based rfc8941 primitives; only the
+	// data section itself is streamed.
+	header, err := br.ReadString(',')
+	if err != nil {
+		return "", nil, fmt.Errorf("dataurl: missing \",\": %w", err)
+	}
+	header = header[:len(header)-1]
+
+	const prefix = "data:"
+	if !strings.HasPrefix(header, prefix) {
+		return "", nil, fmt.Errorf("dataurl: missing %q prefix", prefix)
+	}
+	header = header[len(prefix):]
+
+	mediatype, params, isBase64, err := parseHeader(header)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if isBase64 {
+		if err := decodeBase64Lenient(br, w); err != nil {
+			return "", nil, err
+		}
+	} else {
+		if err := decodePercent(br, w); err != nil {
+			return "", nil, err
+		}
+	}
+
+	return mediatype, params, nil
+}
+
+// parseHeader parses the "[<mediatype>][;base64]" portion of a data:
+// URL, i.e. everything between "data:" and the first ",".
+func parseHeader(header string) (mediatype string, params map[string]string, isBase64 bool, err error) {
+	params = map[string]string{}
+
+	// type "/" subtype is a bare rfc8941 Token (tchar / ":" / "/"), so
+	// reuse ParseToken directly: it naturally stops at the first ";"
+	// since ";" is not a tchar.
+	if header != "" && isMediaTypeStartByte(header[0]) {
+		token, err := rfc8941.ParseToken(&header)
+		if err != nil {
+			return "", nil, false, fmt.Errorf("dataurl: parsing mediatype: %w", err)
+		}
+		mediatype = string(token)
+		if !strings.Contains(mediatype, "/") {
+			return "", nil, false, fmt.Errorf("dataurl: mediatype %q has no \"/\"", mediatype)
+		}
+	}
+
+	for header != "" {
+		if header[0] != ';' {
+			return "", nil, false, fmt.Errorf("dataurl: unexpected %q before parameters", header)
+		}
+		header = header[1:]
+
+		attribute, err := rfc8941.ParseToken(&header)
+		if err != nil {
+			return "", nil, false, fmt.Errorf("dataurl: parsing parameter name: %w", err)
+		}
+
+		if header == "" || header[0] != '=' {
+			// A bare attribute with no value; "base64" is the only one
+			// RFC 2397 defines, but any other is passed through with an
+			// empty value so round-tripping isn't lossy.
+			if string(attribute) == "base64" {
+				isBase64 = true
+			} else {
+				params[string(attribute)] = ""
+			}
+			continue
+		}
+		header = header[1:]
+
+		var value string
+		if header != "" && header[0] == '"' {
+			value, err = rfc8941.ParseString(&header)
+		} else {
+			var token rfc8941.Token
+			token, err = rfc8941.ParseToken(&header)
+			value = string(token)
+		}
+		if err != nil {
+			return "", nil, false, fmt.Errorf("dataurl: parsing parameter %q value: %w", attribute, err)
+		}
+		params[string(attribute)] = value
+	}
+
+	return mediatype, params, isBase64, nil
+}
+
+func isMediaTypeStartByte(b byte) bool {
+	return rfc7230.IsTChar(b) || b == ':' || b == '/'
+}
+
+// decodeBase64Lenient decodes the base64 data section of a data: URL
+// into w, synthesizing "=" padding if it was omitted -- the same
+// leniency rfc8941.ParseBinary applies -- while still rejecting
+// characters outside the base64 alphabet and embedded line feeds, per
+// [RFC4648] Sections 3.1 and 3.3.
+func decodeBase64Lenient(r io.Reader, w io.Writer) error {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return fmt.Errorf("dataurl: reading data: %w", err)
+	}
+	if bytes.IndexByte(b, '\n') != -1 {
+		return fmt.Errorf("dataurl: embedded line feed in base64 data")
+	}
+	content := string(b)
+	for i := 0; i < len(content); i++ {
+		c := content[i]
+		if !isBase64CharByte(c) {
+			return fmt.Errorf("dataurl: invalid base64 character %q", c)
+		}
+	}
+
+	trimmed := strings.TrimRight(content, "=")
+	padded := trimmed + strings.Repeat("=", (4-len(trimmed)%4)%4)
+	decoded, err := base64.StdEncoding.DecodeString(padded)
+	if err != nil {
+		return fmt.Errorf("dataurl: decoding base64 data: %w", err)
+	}
+	_, err = w.Write(decoded)
+	return err
+}
+
+func isBase64CharByte(b byte) bool {
+	return (b >= 'A' && b <= 'Z') || (b >= 'a' && b <= 'z') || (b >= '0' && b <= '9') || b == '+' || b == '/' || b == '='
+}
+
+// decodePercent copies the (non-base64) data section of a data: URL
+// into w, decoding "%XX" escapes along the way.
+func decodePercent(r io.Reader, w io.Writer) error {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return fmt.Errorf("dataurl: reading data: %w", err)
+	}
+	s := string(b)
+	for len(s) > 0 {
+		if s[0] == '%' {
+			if len(s) < 3 {
+				return fmt.Errorf("dataurl: truncated %%-escape")
+			}
+			octet, err := strconv.ParseUint(s[1:3], 16, 8)
+			if err != nil {
+				return fmt.Errorf("dataurl: invalid %%-escape %q", s[:3])
+			}
+			if _, err := w.Write([]byte{byte(octet)}); err != nil {
+				return err
+			}
+			s = s[3:]
+			continue
+		}
+		if _, err := w.Write([]byte{s[0]}); err != nil {
+			return err
+		}
+		s = s[1:]
+	}
+	return nil
+}
+
+// Encode serializes mediatype, params, and data as a data: URL. If
+// base64Encode is true, the data section is base64-encoded (with "="
+// padding, following [RFC4648]); otherwise it is percent-encoded.
+func Encode(mediatype string, params map[string]string, data []byte, base64Encode bool) string {
+	var buf bytes.Buffer
+	if err := EncodeStream(&buf, mediatype, params, data, base64Encode); err != nil {
+		// EncodeStream only fails if w.Write fails, and bytes.Buffer's
+		// Write never does.
+		panic(err)
+	}
+	return buf.String()
+}
+
+// EncodeStream is Encode, but it writes directly to w and never holds
+// the base64- or percent-encoded data section in memory as a whole, so
+// it is the preferred entry point for a large payload.
+func EncodeStream(w io.Writer, mediatype string, params map[string]string, data []byte, base64Encode bool) error {
+	if _, err := io.WriteString(w, "data:"+mediatype); err != nil {
+		return err
+	}
+
+	attributes := make([]string, 0, len(params))
+	for attribute := range params {
+		attributes = append(attributes, attribute)
+	}
+	sort.Strings(attributes)
+	for _, attribute := range attributes {
+		if _, err := io.WriteString(w, ";"+attribute); err != nil {
+			return err
+		}
+		if value := params[attribute]; value != "" {
+			if _, err := io.WriteString(w, "="+value); err != nil {
+				return err
+			}
+		}
+	}
+
+	if base64Encode {
+		if _, err := io.WriteString(w, ";base64"); err != nil {
+			return err
+		}
+	}
+	if _, err := io.WriteString(w, ","); err != nil {
+		return err
+	}
+
+	if base64Encode {
+		enc := base64.NewEncoder(base64.StdEncoding, w)
+		if _, err := enc.Write(data); err != nil {
+			return err
+		}
+		return enc.Close()
+	}
+	for _, b := range data {
+		if isURLSafeByte(b) {
+			if _, err := w.Write([]byte{b}); err != nil {
+				return err
+			}
+		} else {
+			if _, err := fmt.Fprintf(w, "%%%02X", b); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// isURLSafeByte reports whether b can appear unescaped in the data
+// section of a data: URL: unreserved characters, plus a handful of
+// sub-delims and gen-delims that need no escaping there since "," and
+// the end of the URL are the only things that could terminate parsing,
+// and "," on its own is handled by the caller via escaping.
+func isURLSafeByte(b byte) bool {
+	switch {
+	case b >= 'A' && b <= 'Z', b >= 'a' && b <= 'z', b >= '0' && b <= '9':
+		return true
+	}
+	switch b {
+	case '-', '_', '.', '~', '!', '*', '\'', '(', ')':
+		return true
+	}
+	return false
+}
@@ -0,0 +1,81 @@
+package dataurl
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		URL        string
+		WantMedia  string
+		WantParams map[string]string
+		WantData   string
+		WantErr    bool
+	}{
+		{`data:,hello%20world`, "", map[string]string{}, "hello world", false},
+		{`data:text/plain;charset=utf-8,hi`, "text/plain", map[string]string{"charset": "utf-8"}, "hi", false},
+		{`data:image/png;base64,cHVtcGtpbg==`, "image/png", map[string]string{}, "pumpkin", false},
+		{`data:image/png;base64,cHVtcGtpbg`, "image/png", map[string]string{}, "pumpkin", false},
+		{`nope:,x`, "", nil, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.URL, func(t *testing.T) {
+			mediatype, params, data, err := Decode(tt.URL)
+			if tt.WantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if mediatype != tt.WantMedia {
+				t.Errorf("mediatype = %q, want %q", mediatype, tt.WantMedia)
+			}
+			if !reflect.DeepEqual(params, tt.WantParams) {
+				t.Errorf("params = %#v, want %#v", params, tt.WantParams)
+			}
+			if string(data) != tt.WantData {
+				t.Errorf("data = %q, want %q", data, tt.WantData)
+			}
+		})
+	}
+}
+
+func TestEncodeDecodeRoundTrip(t *testing.T) {
+	url := Encode("text/plain", map[string]string{"charset": "utf-8"}, []byte("pumpkin spice"), true)
+	mediatype, params, data, err := Decode(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mediatype != "text/plain" {
+		t.Errorf("mediatype = %q, want %q", mediatype, "text/plain")
+	}
+	if params["charset"] != "utf-8" {
+		t.Errorf("params[charset] = %q, want %q", params["charset"], "utf-8")
+	}
+	if string(data) != "pumpkin spice" {
+		t.Errorf("data = %q, want %q", data, "pumpkin spice")
+	}
+}
+
+func TestDecodeStreamRejectsLineFeedInBase64(t *testing.T) {
+	var buf bytes.Buffer
+	_, _, err := DecodeStream(bytes.NewReader([]byte("data:;base64,cHVt\ncGtpbg==")), &buf)
+	if err == nil {
+		t.Fatal("expected an error for an embedded line feed")
+	}
+}
+
+func TestEncodeStreamPercentEncodesUnsafeBytes(t *testing.T) {
+	var buf bytes.Buffer
+	if err := EncodeStream(&buf, "text/plain", nil, []byte("a,b"), false); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := buf.String(), "data:text/plain,a%2Cb"; got != want {
+		t.Errorf("EncodeStream() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,107 @@
+package fetch
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrDeadlineExceeded is delivered in place of AbortError/context.Canceled
+// when a RequestInit ConnectDeadline/HeaderDeadline/BodyDeadline, or a
+// ReadableStream's SetReadDeadline/SetWriteDeadline, elapses before the
+// operation it bounds completes. It is distinct from an AbortSignal firing,
+// so callers can tell a self-imposed timeout from a deliberate cancel.
+var ErrDeadlineExceeded = errors.New("fetch: deadline exceeded")
+
+// deadlineTimer is a resettable, fire-once deadline: the same pattern
+// net.Conn implementations use internally (e.g. the gonet package's
+// deadlineTimer) to let a blocking operation abort via select instead of
+// needing to know about deadlines itself. Channel returns a chan struct{}
+// that is closed by the *time.Timer started in Set when it fires; Set
+// replaces that timer (stopping any previous one) on every call, so it
+// composes as a plain "set/clear the deadline" operation regardless of
+// whether a previous deadline already fired.
+//
+// gen guards against a re-arm racing an in-flight fire (e.g. Set called a
+// second time, as SetReadDeadline's doc comment endorses for a
+// multi-chunk read, while the first deadline's AfterFunc callback is
+// already running): each Set bumps gen and hands the AfterFunc closure
+// its own snapshot of both gen and a freshly allocated channel, so a
+// callback belonging to a since-superseded generation finds its gen
+// stale and returns without closing a channel or invoking an onExpire
+// that a later Set has since replaced.
+type deadlineTimer struct {
+	mu    sync.Mutex
+	timer *time.Timer
+	c     chan struct{}
+	gen   uint64
+}
+
+func newDeadlineTimer() *deadlineTimer {
+	return &deadlineTimer{c: make(chan struct{})}
+}
+
+// Channel returns the channel that closes when the deadline fires.
+func (d *deadlineTimer) Channel() <-chan struct{} {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.c
+}
+
+// fired reports whether the deadline has already closed Channel.
+func (d *deadlineTimer) fired() bool {
+	select {
+	case <-d.Channel():
+		return true
+	default:
+		return false
+	}
+}
+
+// Set arms the deadline for t, calling onExpire (if non-nil) when it fires.
+// A zero t disarms the deadline without firing it -- matching the
+// net.Conn.SetDeadline convention of a zero time.Time meaning "no
+// deadline" -- and Stop is Set(time.Time{}, nil).
+func (d *deadlineTimer) Set(t time.Time, onExpire func()) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.timer != nil {
+		d.timer.Stop()
+		d.timer = nil
+	}
+	// Always start this generation on its own channel, not just when the
+	// previous one already fired: a fired-but-not-yet-observed-as-such
+	// callback (still between its stale check and its close(c) call) must
+	// never be able to close the channel this generation hands out.
+	d.gen++
+	gen := d.gen
+	d.c = make(chan struct{})
+	c := d.c
+
+	if t.IsZero() {
+		return
+	}
+
+	d.timer = time.AfterFunc(time.Until(t), func() {
+		d.mu.Lock()
+		stale := gen != d.gen
+		d.mu.Unlock()
+		if stale {
+			// Superseded by a later Set call before we got here; c and
+			// onExpire belong to a deadline that's no longer current, so
+			// firing them now would signal an expiry that never happened
+			// for the deadline actually in effect.
+			return
+		}
+		close(c)
+		if onExpire != nil {
+			onExpire()
+		}
+	})
+}
+
+// Stop disarms the deadline without firing it.
+func (d *deadlineTimer) Stop() {
+	d.Set(time.Time{}, nil)
+}
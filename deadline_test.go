@@ -0,0 +1,175 @@
+package fetch
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestDeadlineTimerFiresOnce(t *testing.T) {
+	d := newDeadlineTimer()
+	fired := make(chan struct{})
+	d.Set(time.Now().Add(10*time.Millisecond), func() { close(fired) })
+
+	select {
+	case <-fired:
+	case <-time.After(time.Second):
+		t.Fatal("deadline never fired")
+	}
+	if !d.fired() {
+		t.Error("fired() = false after firing")
+	}
+}
+
+func TestDeadlineTimerZeroClears(t *testing.T) {
+	d := newDeadlineTimer()
+	fired := false
+	d.Set(time.Now().Add(10*time.Millisecond), func() { fired = true })
+	d.Set(time.Time{}, nil)
+
+	time.Sleep(30 * time.Millisecond)
+	if fired {
+		t.Error("deadline fired after being cleared")
+	}
+	if d.fired() {
+		t.Error("fired() = true after being cleared")
+	}
+}
+
+// TestDeadlineTimerReArmDuringFireIsNotStale re-arms Set repeatedly while
+// each previous deadline is on the verge of firing, as SetReadDeadline's
+// doc comment endorses for a multi-chunk read. A fire that's in flight
+// when a later Set supersedes it must not signal onExpire for the
+// deadline that's actually current.
+func TestDeadlineTimerReArmDuringFireIsNotStale(t *testing.T) {
+	d := newDeadlineTimer()
+
+	for i := 0; i < 200; i++ {
+		d.Set(time.Now().Add(time.Millisecond), func() {})
+		time.Sleep(900 * time.Microsecond)
+	}
+
+	start := time.Now()
+	fired := make(chan struct{})
+	d.Set(time.Now().Add(30*time.Millisecond), func() { close(fired) })
+	select {
+	case <-fired:
+		if elapsed := time.Since(start); elapsed < 25*time.Millisecond {
+			t.Errorf("onExpire ran after %v, want >= 30ms: a superseded deadline fired early", elapsed)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("final deadline never fired")
+	}
+}
+
+func TestSetReadDeadlineAbortsBlockedRead(t *testing.T) {
+	pr, pw := io.Pipe()
+	defer pw.Close()
+
+	stream := newReadableStream(pr)
+	stream.SetReadDeadline(time.Now().Add(20 * time.Millisecond))
+
+	result := <-stream.Read(make([]byte, 4))
+	if !errors.Is(result.Err, ErrDeadlineExceeded) {
+		t.Errorf("Read() err = %v, want ErrDeadlineExceeded", result.Err)
+	}
+}
+
+func TestSetReadDeadlineZeroClears(t *testing.T) {
+	pr, pw := io.Pipe()
+	stream := newReadableStream(pr)
+	stream.SetReadDeadline(time.Now().Add(20 * time.Millisecond))
+	stream.SetReadDeadline(time.Time{})
+
+	go func() {
+		time.Sleep(40 * time.Millisecond)
+		pw.Write([]byte("hi"))
+		pw.Close()
+	}()
+
+	result := <-stream.Read(make([]byte, 4))
+	if result.Err != nil {
+		t.Errorf("Read() err = %v, want nil", result.Err)
+	}
+}
+
+func TestSetWriteDeadlineFailsTeeBranches(t *testing.T) {
+	pr, pw := io.Pipe()
+	defer pw.Close()
+
+	stream := newReadableStream(pr)
+	stream.SetWriteDeadline(time.Now().Add(-time.Millisecond))
+
+	a, b := stream.Tee(0)
+	for _, s := range []*ReadableStream{a, b} {
+		result := <-s.Read(make([]byte, 4))
+		if !errors.Is(result.Err, ErrDeadlineExceeded) {
+			t.Errorf("Read() err = %v, want ErrDeadlineExceeded", result.Err)
+		}
+	}
+}
+
+func TestConnectTransportTranslatesTimeout(t *testing.T) {
+	transport := connectTransport(time.Now().Add(-time.Millisecond))
+	_, err := transport.DialContext(context.Background(), "tcp", "127.0.0.1:1")
+	if !errors.Is(err, ErrDeadlineExceeded) {
+		t.Errorf("DialContext() err = %v, want ErrDeadlineExceeded", err)
+	}
+}
+
+func TestFetchHeaderDeadlineExceeded(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(50 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	result := <-Fetch(srv.URL, &RequestInit{HeaderDeadline: time.Now().Add(10 * time.Millisecond)})
+	if !errors.Is(result.Err, ErrDeadlineExceeded) {
+		t.Errorf("Fetch() err = %v, want ErrDeadlineExceeded", result.Err)
+	}
+}
+
+func TestFetchHeaderDeadlineNotExceeded(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	result := <-Fetch(srv.URL, &RequestInit{HeaderDeadline: time.Now().Add(time.Second)})
+	if result.Err != nil {
+		t.Fatalf("Fetch() err = %v, want nil", result.Err)
+	}
+	if result.Response.Status != http.StatusOK {
+		t.Errorf("Status = %d, want %d", result.Response.Status, http.StatusOK)
+	}
+}
+
+func TestFetchBodyDeadlineExceeded(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("first chunk"))
+		w.(http.Flusher).Flush()
+		time.Sleep(50 * time.Millisecond)
+		w.Write([]byte("second chunk"))
+	}))
+	defer srv.Close()
+
+	result := <-Fetch(srv.URL, &RequestInit{BodyDeadline: time.Now().Add(20 * time.Millisecond)})
+	if result.Err != nil {
+		t.Fatalf("Fetch() err = %v, want nil", result.Err)
+	}
+
+	stream := result.Response.Body.Stream()
+	var readErr error
+	for readErr == nil {
+		r := <-stream.Read(make([]byte, 4))
+		readErr = r.Err
+	}
+	if !errors.Is(readErr, ErrDeadlineExceeded) {
+		t.Errorf("Read() err = %v, want ErrDeadlineExceeded", readErr)
+	}
+}
@@ -1,64 +1,189 @@
 package fetch
 
 import (
+	"bytes"
 	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"net/url"
-)
-
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/jcbhmr/go-fetch/auth"
+	"github.com/jcbhmr/go-fetch/rfc9421"
+)
 
+// https://fetch.spec.whatwg.org/#concept-request
 type conceptRequest struct {
-	method     string
-	url        *url.URL
-	headerList map[string]string
-	body       *io.Reader
-	keepalive  bool
+	method      string
+	url         *url.URL
+	headers     *Headers
+	body        io.Reader
+	contentType string
+	keepalive   bool
+	redirect    string
+
+	connectDeadline time.Time
+	headerDeadline  time.Time
+	bodyDeadline    time.Time
 }
 
 type Request struct {
 	request *conceptRequest
-	headers *Headers
-	signal  *context.Context
-	body    *io.Reader
+	signal  *AbortSignal
 }
 
-func NewRequest(input string, init *RequestInit) *Request {
+// bodyReader converts a RequestInit.Body value into a stream to send and,
+// if the body type implies one, a Content-Type to send it with. Accepted
+// types are string, []byte, io.Reader, url.Values, and *FormData.
+func bodyReader(body any) (io.Reader, string, error) {
+	switch body := body.(type) {
+	case nil:
+		return nil, "", nil
+	case string:
+		return strings.NewReader(body), "", nil
+	case []byte:
+		return bytes.NewReader(body), "", nil
+	case io.Reader:
+		return body, "", nil
+	case url.Values:
+		return strings.NewReader(body.Encode()), "application/x-www-form-urlencoded;charset=UTF-8", nil
+	case *FormData:
+		return body.reader()
+	default:
+		return nil, "", fmt.Errorf("fetch: unsupported RequestInit.Body type %T", body)
+	}
+}
+
+func NewRequest(input string, init *RequestInit) (*Request, error) {
 	var headers *Headers
 	if init != nil && init.Headers != nil {
 		headers = init.Headers
 	} else {
-		headers = NewHeaders(nil)
+		var err error
+		headers, err = NewHeaders(nil)
+		if err != nil {
+			return nil, err
+		}
 	}
-	url, err := url.Parse(input)
+
+	parsedURL, err := url.Parse(input)
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
-	var method string
+
+	method := "GET"
 	if init != nil && init.Method != nil {
 		method = *init.Method
-	} else {
-		method = "GET"
 	}
+
+	var body io.Reader
+	var contentType string
+	if init != nil && init.Body != nil {
+		body, contentType, err = bodyReader(init.Body)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var keepalive bool
+	if init != nil && init.Keepalive != nil {
+		keepalive = *init.Keepalive
+	}
+
+	redirect := "follow"
+	if init != nil && init.Redirect != nil {
+		redirect = *init.Redirect
+	}
+
+	var signal *AbortSignal
+	if init != nil && init.Signal != nil {
+		signal = init.Signal
+	}
+
+	var connectDeadline, headerDeadline, bodyDeadline time.Time
+	if init != nil {
+		connectDeadline = init.ConnectDeadline
+		headerDeadline = init.HeaderDeadline
+		bodyDeadline = init.BodyDeadline
+	}
+
 	return &Request{
 		request: &conceptRequest{
-			method:     method,
-			url:        url,
-			headerList: headers.headerList,
-			body:       nil,
-			keepalive:  false,
+			method:      method,
+			url:         parsedURL,
+			headers:     headers,
+			body:        body,
+			contentType: contentType,
+			keepalive:   keepalive,
+			redirect:    redirect,
+
+			connectDeadline: connectDeadline,
+			headerDeadline:  headerDeadline,
+			bodyDeadline:    bodyDeadline,
 		},
-		headers: headers,
-		signal:  nil,
-		body:    nil,
+		signal: signal,
+	}, nil
+}
+
+// Method returns the request's HTTP method, e.g. "GET".
+func (r *Request) Method() string {
+	return r.request.method
+}
+
+// URL returns the request's target URL.
+func (r *Request) URL() *url.URL {
+	return r.request.url
+}
+
+// Headers returns the request's headers.
+func (r *Request) Headers() *Headers {
+	return r.request.headers
+}
+
+// Body returns the request's body stream, or nil if it has none.
+func (r *Request) Body() io.Reader {
+	return r.request.body
+}
+
+// Context returns the context.Context that Signal, if any, was derived
+// from, for clients that want to honor request cancellation/timeouts.
+func (r *Request) Context() context.Context {
+	if r.signal != nil {
+		return r.signal.Context()
 	}
+	return context.Background()
+}
+
+// ConnectDeadline returns the deadline by which DefaultClient's TCP
+// connect must complete, or the zero time.Time if RequestInit.
+// ConnectDeadline was unset.
+func (r *Request) ConnectDeadline() time.Time {
+	return r.request.connectDeadline
+}
+
+// HeaderDeadline returns the deadline by which response headers must
+// arrive, or the zero time.Time if RequestInit.HeaderDeadline was unset.
+func (r *Request) HeaderDeadline() time.Time {
+	return r.request.headerDeadline
+}
+
+// BodyDeadline returns the deadline by which the response body must be
+// fully read, or the zero time.Time if RequestInit.BodyDeadline was
+// unset.
+func (r *Request) BodyDeadline() time.Time {
+	return r.request.bodyDeadline
 }
 
 type RequestInit struct {
 	Method         *string
 	Headers        *Headers
-	Body           **string
+	Body           any
 	Referrer       *string
 	ReferrerPolicy *string
 	Mode           *string
@@ -67,29 +192,352 @@ type RequestInit struct {
 	Redirect       *string
 	Integrity      *string
 	Keepalive      *bool
-	Signal         *context.Context
+	Signal         *AbortSignal
 	Duplex         *string
 	Priority       *string
 	Window         *any
+
+	// ConnectDeadline, if non-zero, bounds DefaultClient's TCP connect
+	// for this request; exceeding it fails with ErrDeadlineExceeded.
+	ConnectDeadline time.Time
+	// HeaderDeadline, if non-zero, bounds how long DefaultClient waits
+	// for response headers; exceeding it fails with ErrDeadlineExceeded.
+	// It does not bound reading the response body -- use BodyDeadline
+	// for that.
+	HeaderDeadline time.Time
+	// BodyDeadline, if non-zero, is applied to the response Body's
+	// ReadableStream as its read deadline (see
+	// ReadableStream.SetReadDeadline) once headers arrive, bounding how
+	// long the body may take to finish streaming.
+	BodyDeadline time.Time
 }
 
-type Response struct{}
+// https://fetch.spec.whatwg.org/#concept-response
+type Response struct {
+	Status     int
+	StatusText string
+	Headers    *Headers
+	URL        string
+	Redirected bool
+	Type       string
+	Body       *Body
+}
 
 type FetchResult struct {
 	*Response
 	Err error
 }
 
-func Fetch(input string, init *RequestInit) <-chan FetchResult {
-	c := make(chan FetchResult)
+const maxRedirects = 10
+
+// ClientID identifies a Client implementation, analogous to how a transport
+// like efixler/scrape's Chrome/CDP client tags the responses it produces so
+// callers can tell which backend served a given Request.
+type ClientID string
+
+// Client performs a Request and returns its Response, letting callers swap
+// the transport Fetch uses to satisfy a request — for example, a
+// headless-browser client (see internal/headless) that renders JavaScript
+// before returning the body, instead of the default net/http transport.
+type Client interface {
+	Do(req *Request) (*Response, error)
+	Identifier() ClientID
+}
+
+// FetchOptions customizes how Fetch dispatches a Request. The zero value
+// uses DefaultClient and sends the request unsigned.
+type FetchOptions struct {
+	// Client is the transport Fetch uses to perform the request. If nil,
+	// Fetch uses DefaultClient.
+	Client Client
+
+	// Sign, if non-nil, signs the request with an RFC 9421 HTTP Message
+	// Signature before dispatching it: see signRequest.
+	Sign *rfc9421.SignOptions
+
+	// CredentialStore resolves Basic auth credentials for the request's
+	// URL when it has no Authorization header, and for any host Redirect
+	// "follow" lands on, in place of Fetch's own global credential
+	// resolution. This is the "WithCredentialStore" option mentioned in
+	// the API's design notes — library users needing a Keychain,
+	// Secret Service, or Vault backend set this instead of an
+	// auth.NetrcStore.
+	CredentialStore auth.CredentialStore
+}
+
+// signRequest signs req per opts (see rfc9421.Sign), buffering its body
+// so that a "content-digest" covered component can be computed over it,
+// and writing the resulting Content-Digest/Signature-Input/Signature
+// headers back onto req.
+func signRequest(req *Request, opts rfc9421.SignOptions) error {
+	var body []byte
+	if req.request.body != nil {
+		var err error
+		body, err = io.ReadAll(req.request.body)
+		if err != nil {
+			return fmt.Errorf("fetch: reading request body to sign: %w", err)
+		}
+		req.request.body = bytes.NewReader(body)
+	}
+
+	header := make(http.Header)
+	for _, entry := range req.request.headers.Entries() {
+		header.Add(entry[0], entry[1])
+	}
+
+	msg := rfc9421.Message{Method: req.request.method, URL: req.request.url, Header: header}
+	if err := rfc9421.Sign(msg, body, opts); err != nil {
+		return fmt.Errorf("fetch: signing request: %w", err)
+	}
+
+	for _, name := range []string{"Content-Digest", "Signature-Input", "Signature"} {
+		if value := header.Get(name); value != "" {
+			if err := req.request.headers.Set(name, value); err != nil {
+				return fmt.Errorf("fetch: setting %s header: %w", name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// injectCredentials sets req's Authorization header to Basic auth from
+// store, if req doesn't already have one and store has credentials for
+// req's host.
+func injectCredentials(req *Request, store auth.CredentialStore) error {
+	existing, err := req.request.headers.Get("Authorization")
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		return nil
+	}
+
+	user, pass, ok := store.Lookup(req.request.url)
+	if !ok {
+		return nil
+	}
+
+	token := base64.StdEncoding.EncodeToString([]byte(user + ":" + pass))
+	return req.request.headers.Set("Authorization", "Basic "+token)
+}
+
+// HTTPClientID identifies DefaultClient, the net/http-backed Client.
+const HTTPClientID ClientID = "net/http"
+
+// httpClient is the default Client, implementing the WHATWG fetch
+// algorithm against net/http: it builds the outgoing request from Method,
+// Headers, and Body; applies Redirect ("follow"/"error"/"manual") via a
+// custom CheckRedirect; and propagates Signal so cancelling the
+// context.Context aborts the in-flight request.
+type httpClient struct {
+	// credentialStore, if non-nil, re-authenticates a "follow" redirect
+	// that lands on a different host than the one it has credentials
+	// for. net/http already strips Authorization when redirecting
+	// cross-host, so nothing else is needed to avoid leaking the
+	// original host's credentials.
+	credentialStore auth.CredentialStore
+}
+
+// DefaultClient is the Client Fetch uses when FetchOptions.Client is nil.
+var DefaultClient Client = &httpClient{}
+
+func (*httpClient) Identifier() ClientID {
+	return HTTPClientID
+}
+
+// connectTransport returns an http.Transport whose DialContext fails with
+// ErrDeadlineExceeded (rather than the dialer's own timeout error) if the
+// connect doesn't finish by connectDeadline, for RequestInit.ConnectDeadline.
+func connectTransport(connectDeadline time.Time) *http.Transport {
+	var dialer net.Dialer
+	return &http.Transport{
+		DialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
+			dialCtx, cancel := context.WithDeadline(ctx, connectDeadline)
+			defer cancel()
+			conn, err := dialer.DialContext(dialCtx, network, addr)
+			if err != nil && dialCtx.Err() == context.DeadlineExceeded {
+				return nil, ErrDeadlineExceeded
+			}
+			return conn, err
+		},
+	}
+}
+
+// cancelOnCloseReader calls cancel when Close is called, releasing the
+// http.Request's context once a response body backed by a HeaderDeadline's
+// headerCtx is done being read, rather than as soon as headers arrive.
+type cancelOnCloseReader struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (r *cancelOnCloseReader) Close() error {
+	err := r.ReadCloser.Close()
+	r.cancel()
+	return err
+}
+
+func (hc *httpClient) Do(req *Request) (*Response, error) {
+	// HeaderDeadline is enforced by cancelling headerCtx, rather than
+	// deriving it with context.WithDeadline directly, so that once headers
+	// arrive in time headerTimer.Stop() can disarm it before it has a
+	// chance to also cut off the still-to-be-read response body. headerCtx
+	// is only derived from req.Context() (rather than reused directly) when
+	// a HeaderDeadline is actually set: http.Client ties httpRes.Body's
+	// entire lifetime to this same context, so headerCtxCancel must not be
+	// called until the body is done being read, not unconditionally once Do
+	// returns.
+	headerCtx := req.Context()
+	headerCtxCancel := func() {}
+	headerTimer := newDeadlineTimer()
+	if !req.request.headerDeadline.IsZero() {
+		headerCtx, headerCtxCancel = context.WithCancel(req.Context())
+		headerTimer.Set(req.request.headerDeadline, headerCtxCancel)
+	}
+
+	httpReq, err := http.NewRequestWithContext(headerCtx, req.request.method, req.request.url.String(), req.request.body)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range req.request.headers.Entries() {
+		httpReq.Header.Add(entry[0], entry[1])
+	}
+	if req.request.contentType != "" && httpReq.Header.Get("Content-Type") == "" {
+		httpReq.Header.Set("Content-Type", req.request.contentType)
+	}
+
+	redirected := false
+	client := &http.Client{}
+	if !req.request.connectDeadline.IsZero() {
+		client.Transport = connectTransport(req.request.connectDeadline)
+	}
+	switch req.request.redirect {
+	case "manual":
+		client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		}
+	case "error":
+		client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+			return fmt.Errorf("fetch: redirect to %s not followed in \"error\" redirect mode", req.URL)
+		}
+	default:
+		client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+			redirected = true
+			if len(via) >= maxRedirects {
+				return errors.New("fetch: stopped after 10 redirects")
+			}
+			if hc.credentialStore != nil {
+				if user, pass, ok := hc.credentialStore.Lookup(req.URL); ok {
+					req.SetBasicAuth(user, pass)
+				}
+			}
+			return nil
+		}
+	}
+
+	// Keepalive lets a request outlive the page in a browser; net/http has
+	// no equivalent concept for a native process, so it is recorded on the
+	// request but otherwise unused here.
+	_ = req.request.keepalive
+
+	httpRes, err := client.Do(httpReq)
+	headerDeadlineExceeded := headerTimer.fired()
+	// Headers either arrived or the request failed; either way,
+	// headerDeadline no longer applies, so disarm it before it could fire
+	// during the body read that follows.
+	headerTimer.Stop()
+	if err != nil {
+		headerCtxCancel()
+		if headerDeadlineExceeded {
+			return nil, ErrDeadlineExceeded
+		}
+		return nil, err
+	}
+
+	resHeaders, err := newHeadersFromHTTP(httpRes.Header)
+	if err != nil {
+		headerCtxCancel()
+		return nil, err
+	}
+
+	responseType := "basic"
+	if req.request.redirect == "manual" && httpRes.StatusCode >= 300 && httpRes.StatusCode < 400 {
+		responseType = "opaqueredirect"
+	}
+
+	statusText := strings.TrimSpace(strings.TrimPrefix(httpRes.Status, strconv.Itoa(httpRes.StatusCode)))
+
+	// headerCtxCancel is released once the body is closed, not here -- it
+	// governs httpRes.Body's entire read lifetime, not just the wait for
+	// headers.
+	body := newBody(&cancelOnCloseReader{ReadCloser: httpRes.Body, cancel: headerCtxCancel})
+	if !req.request.bodyDeadline.IsZero() {
+		body.Stream().SetReadDeadline(req.request.bodyDeadline)
+	}
+
+	return &Response{
+		Status:     httpRes.StatusCode,
+		StatusText: statusText,
+		Headers:    resHeaders,
+		URL:        httpRes.Request.URL.String(),
+		Redirected: redirected,
+		Type:       responseType,
+		Body:       body,
+	}, nil
+}
+
+// Fetch performs the request described by input and init, dispatching it
+// through opts.Client if given, or DefaultClient otherwise. The returned
+// channel delivers the response as soon as it is available; the body is
+// read separately through Response.Body.
+func Fetch(input string, init *RequestInit, opts ...FetchOptions) <-chan FetchResult {
+	c := make(chan FetchResult, 1)
 	go func() {
 		defer close(c)
-		res, err := http.Get(input)
+
+		req, err := NewRequest(input, init)
 		if err != nil {
-			c <- FetchResult{Response: nil, Err: err}
+			c <- FetchResult{Err: err}
 			return
 		}
-		c <- FetchResult{Response: &Response{}, Err: nil}
+
+		client := DefaultClient
+		if len(opts) > 0 && opts[0].Client != nil {
+			client = opts[0].Client
+		} else if len(opts) > 0 && opts[0].CredentialStore != nil {
+			client = &httpClient{credentialStore: opts[0].CredentialStore}
+		}
+
+		if len(opts) > 0 && opts[0].CredentialStore != nil {
+			if err := injectCredentials(req, opts[0].CredentialStore); err != nil {
+				c <- FetchResult{Err: err}
+				return
+			}
+		}
+
+		if len(opts) > 0 && opts[0].Sign != nil {
+			if err := signRequest(req, *opts[0].Sign); err != nil {
+				c <- FetchResult{Err: err}
+				return
+			}
+		}
+
+		res, err := client.Do(req)
+		if err != nil {
+			if req.signal != nil && req.signal.Aborted() {
+				c <- FetchResult{Err: &AbortError{Reason: req.signal.Reason()}}
+				return
+			}
+			c <- FetchResult{Err: err}
+			return
+		}
+
+		if req.signal != nil {
+			req.signal.AddEventListener(func(reason error) {
+				res.Body.Close()
+			})
+		}
+		c <- FetchResult{Response: res}
 	}()
 	return c
 }
@@ -1,6 +1,7 @@
 package fetch
 
 import (
+	"errors"
 	"testing"
 )
 
@@ -29,7 +30,10 @@ func TestParseHeaderName(t *testing.T) {
 }
 
 func TestFetch(t *testing.T) {
-	responseResult := <-Fetch("https://jsonplaceholder.typicode.com/todos/1", nil)
+	controller := NewAbortController()
+	responseResult := <-Fetch("https://jsonplaceholder.typicode.com/todos/1", &RequestInit{
+		Signal: controller.Signal(),
+	})
 	if responseResult.Err != nil {
 		t.Errorf("unexpected error: %v", responseResult.Err)
 	}
@@ -38,3 +42,16 @@ func TestFetch(t *testing.T) {
 	}
 	t.Log(responseResult.Response)
 }
+
+func TestFetchAbort(t *testing.T) {
+	controller := NewAbortController()
+	controller.Abort(nil)
+
+	responseResult := <-Fetch("https://jsonplaceholder.typicode.com/todos/1", &RequestInit{
+		Signal: controller.Signal(),
+	})
+	var abortErr *AbortError
+	if !errors.As(responseResult.Err, &abortErr) {
+		t.Errorf("expected an *AbortError, got %v", responseResult.Err)
+	}
+}
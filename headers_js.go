@@ -1,16 +1,144 @@
 package fetch
 
 import (
+	"net/http"
 	"syscall/js"
 )
 
+// https://fetch.spec.whatwg.org/#headers-class
 type Headers struct {
 	js.Value
 }
 
-var jsHeaders = js.Global().Get("Headers")
+var jsHeadersConstructor = js.Global().Get("Headers")
 
-func NewHeaders() (*Headers, error) {
-	jsValue := jsHeaders.New()
+// NewHeaders constructs a Headers backed by the browser's native Headers
+// object. init may be nil, a sequence of name/value pairs ([][2]string or
+// [][]string), or a record ordered by key (map[string]string), mirroring
+// HeadersInit.
+func NewHeaders(init any) (*Headers, error) {
+	if init == nil {
+		return &Headers{jsHeadersConstructor.New()}, nil
+	}
+	return &Headers{jsHeadersConstructor.New(toJSHeadersInit(init))}, nil
+}
+
+func toJSHeadersInit(init any) js.Value {
+	switch init := init.(type) {
+	case map[string]string:
+		obj := js.Global().Get("Object").New()
+		for name, value := range init {
+			obj.Set(name, value)
+		}
+		return obj
+	case [][2]string:
+		array := js.Global().Get("Array").New(len(init))
+		for i, pair := range init {
+			array.SetIndex(i, js.ValueOf([]any{pair[0], pair[1]}))
+		}
+		return array
+	case [][]string:
+		array := js.Global().Get("Array").New(len(init))
+		for i, pair := range init {
+			array.SetIndex(i, js.ValueOf([]any{pair[0], pair[1]}))
+		}
+		return array
+	default:
+		return js.ValueOf(init)
+	}
+}
+
+// newHeadersFromHTTP builds a response Headers from an http.Response's
+// http.Header by appending each value to a fresh native Headers object, the
+// same way the notjs backend preserves Set-Cookie entries for GetSetCookie.
+func newHeadersFromHTTP(h http.Header) (*Headers, error) {
+	jsValue := jsHeadersConstructor.New()
+	for name, values := range h {
+		for _, value := range values {
+			jsValue.Call("append", name, value)
+		}
+	}
 	return &Headers{jsValue}, nil
 }
+
+// https://fetch.spec.whatwg.org/#dom-headers-append
+func (h *Headers) Append(name string, value string) error {
+	h.Value.Call("append", name, value)
+	return nil
+}
+
+// https://fetch.spec.whatwg.org/#dom-headers-delete
+func (h *Headers) Delete(name string) error {
+	h.Value.Call("delete", name)
+	return nil
+}
+
+// https://fetch.spec.whatwg.org/#dom-headers-get
+func (h *Headers) Get(name string) (*string, error) {
+	result := h.Value.Call("get", name)
+	if result.IsNull() {
+		return nil, nil
+	}
+	value := result.String()
+	return &value, nil
+}
+
+// https://fetch.spec.whatwg.org/#dom-headers-getsetcookie
+func (h *Headers) GetSetCookie() []string {
+	result := h.Value.Call("getSetCookie")
+	values := make([]string, result.Length())
+	for i := range values {
+		values[i] = result.Index(i).String()
+	}
+	return values
+}
+
+// https://fetch.spec.whatwg.org/#dom-headers-has
+func (h *Headers) Has(name string) (bool, error) {
+	return h.Value.Call("has", name).Bool(), nil
+}
+
+// https://fetch.spec.whatwg.org/#dom-headers-set
+func (h *Headers) Set(name string, value string) error {
+	h.Value.Call("set", name, value)
+	return nil
+}
+
+// Keys returns the Headers' names, sorted and deduplicated as for iteration.
+func (h *Headers) Keys() []string {
+	return h.collect("keys")
+}
+
+// Values returns the Headers' combined values, in the same order as Keys.
+func (h *Headers) Values() []string {
+	return h.collect("values")
+}
+
+// Entries returns the Headers' name/value pairs, in the same order as Keys
+// and Values.
+func (h *Headers) Entries() [][2]string {
+	iterator := h.Value.Call("entries")
+	var entries [][2]string
+	for {
+		next := iterator.Call("next")
+		if next.Get("done").Bool() {
+			break
+		}
+		pair := next.Get("value")
+		entries = append(entries, [2]string{pair.Index(0).String(), pair.Index(1).String()})
+	}
+	return entries
+}
+
+func (h *Headers) collect(method string) []string {
+	iterator := h.Value.Call(method)
+	var values []string
+	for {
+		next := iterator.Call("next")
+		if next.Get("done").Bool() {
+			break
+		}
+		values = append(values, next.Get("value").String())
+	}
+	return values
+}
@@ -5,15 +5,18 @@ package fetch
 import (
 	"errors"
 	"fmt"
+	"net/http"
 	"regexp"
 	"slices"
+	"sort"
+	"strings"
 
 	"github.com/jcbhmr/go-fetch/rfc8941"
 )
 
-// A header list is a list of zero or more headers. It is initially « ».
+// A header list is a list of zero or more headers. It is initially « ».
 //
-// A header list is essentially a specialized multimap: an ordered list of
+// A header list is essentially a specialized multimap: an ordered list of
 // key-value pairs with potentially duplicate keys. Since headers other than
 // Set-Cookie are always combined when exposed to client-side JavaScript,
 // implementations could choose a more efficient representation, as long as they
@@ -32,7 +35,7 @@ func (h headerList) GetStructuredHeader(name headerName, type_ string) rfc8941.S
 	}
 
 	// 2. Let value be the result of getting name from list.
-	value := h.Get(name)
+	value := h.get(string(name))
 
 	// 3. If value is null, then return null.
 	if value == nil {
@@ -40,7 +43,7 @@ func (h headerList) GetStructuredHeader(name headerName, type_ string) rfc8941.S
 	}
 
 	// 4. Let result be the result of parsing structured fields with input_string set to value and header_type set to type.
-	result, err := rfc8941.TextParse(value, type_)
+	result, err := rfc8941.TextParse([]byte(*value), type_)
 	// 5. If parsing failed, then return null.
 	if err != nil {
 		return nil
@@ -49,7 +52,23 @@ func (h headerList) GetStructuredHeader(name headerName, type_ string) rfc8941.S
 	return result
 }
 
-// 
+// To set a structured field value given a header name name, a structured
+// field value value, and a header list list, run these steps. They return
+// an error if serializing value fails.
+func (h *headerList) SetStructuredHeader(name headerName, value rfc8941.StructuredFieldValue) error {
+	// 1. Let serialized_value be the result of serializing structured fields with value.
+	serializedValue, err := rfc8941.TextSerialize(value)
+	// 2. If serializing failed, then return that error.
+	if err != nil {
+		return err
+	}
+	// 3. Set name to serialized_value in list.
+	h.set(conceptHeaderName(name), conceptHeaderValue(serializedValue))
+	return nil
+}
+
+type headerName = string
+
 type header2 struct {
 	Name  conceptHeaderName
 	Value conceptHeaderValue
@@ -95,66 +114,356 @@ func normalize(potentialValue []byte) []byte {
 	return v
 }
 
-// https://fetch.spec.whatwg.org/#typedefdef-headersinit
-type HeadersInit interface {
-	[][]string|map[string]string
+// https://fetch.spec.whatwg.org/#concept-header-list-contains
+func (h headerList) contains(name string) bool {
+	return h.indexOf(name) != -1
 }
 
-// https://fetch.spec.whatwg.org/#headers-class
-type Headers struct {
-	headerList     conceptHeaderList
-	guard headersGuard
+func (h headerList) indexOf(name string) int {
+	for i, header := range h {
+		if strings.EqualFold(string(header.Name), name) {
+			return i
+		}
+	}
+	return -1
+}
+
+// https://fetch.spec.whatwg.org/#concept-header-list-get
+func (h headerList) get(name string) *string {
+	if !h.contains(name) {
+		return nil
+	}
+	// If name is `Set-Cookie`, then return the value of the first header in
+	// list whose name is name; combining Set-Cookie values with ", " would
+	// produce a string that cannot be parsed back into individual cookies.
+	if strings.EqualFold(name, "set-cookie") {
+		for _, header := range h {
+			if strings.EqualFold(string(header.Name), name) {
+				value := string(header.Value)
+				return &value
+			}
+		}
+	}
+	var values []string
+	for _, header := range h {
+		if strings.EqualFold(string(header.Name), name) {
+			values = append(values, string(header.Value))
+		}
+	}
+	combined := strings.Join(values, ", ")
+	return &combined
+}
+
+// https://fetch.spec.whatwg.org/#concept-header-list-get-set-cookie
+func (h headerList) getSetCookie() []string {
+	values := []string{}
+	for _, header := range h {
+		if strings.EqualFold(string(header.Name), "set-cookie") {
+			values = append(values, string(header.Value))
+		}
+	}
+	return values
+}
+
+// https://fetch.spec.whatwg.org/#concept-header-list-append
+func (h *headerList) append(name conceptHeaderName, value conceptHeaderValue) {
+	// 1. If list contains name, then set name to the first such header's name within list.
+	if i := h.indexOf(string(name)); i != -1 {
+		name = (*h)[i].Name
+	}
+	// 2. Append (name, value) to list.
+	*h = append(*h, header2{Name: name, Value: value})
+}
+
+// https://fetch.spec.whatwg.org/#concept-header-list-delete
+func (h *headerList) delete(name string) {
+	kept := (*h)[:0]
+	for _, header := range *h {
+		if !strings.EqualFold(string(header.Name), name) {
+			kept = append(kept, header)
+		}
+	}
+	*h = kept
+}
+
+// https://fetch.spec.whatwg.org/#concept-header-list-set
+func (h *headerList) set(name conceptHeaderName, value conceptHeaderValue) {
+	// 1. If list contains name, then set the value of the first header in
+	// list whose name is name to value and remove the others.
+	i := h.indexOf(string(name))
+	if i == -1 {
+		// 2. Otherwise, append (name, value) to list.
+		*h = append(*h, header2{Name: name, Value: value})
+		return
+	}
+	(*h)[i] = header2{Name: name, Value: value}
+	kept := (*h)[:i+1]
+	for _, header := range (*h)[i+1:] {
+		if !strings.EqualFold(string(header.Name), string(name)) {
+			kept = append(kept, header)
+		}
+	}
+	*h = kept
+}
+
+// https://fetch.spec.whatwg.org/#concept-header-list-sort-and-combine
+func (h headerList) sortAndCombine() []header2 {
+	seen := map[string]bool{}
+	names := make([]string, 0, len(h))
+	for _, header := range h {
+		lower := strings.ToLower(string(header.Name))
+		if !seen[lower] {
+			seen[lower] = true
+			names = append(names, lower)
+		}
+	}
+	sort.Strings(names)
+
+	headers := make([]header2, 0, len(names))
+	for _, name := range names {
+		if name == "set-cookie" {
+			for _, value := range h.getSetCookie() {
+				headers = append(headers, header2{Name: conceptHeaderName(name), Value: conceptHeaderValue(value)})
+			}
+			continue
+		}
+		value := h.get(name)
+		headers = append(headers, header2{Name: conceptHeaderName(name), Value: conceptHeaderValue(*value)})
+	}
+	return headers
 }
 
 // https://fetch.spec.whatwg.org/#headers-guard
 type headersGuard string
 
-func validate(nameValue struct{Name string;Value string}, headers *Headers) (bool, error) {
-	name := nameValue.Name
-	value := nameValue.Value
-	if headerName, err := newConceptHeaderName([]byte(name)); err != nil {
-		return false, err
+const (
+	guardImmutable     headersGuard = "immutable"
+	guardRequest       headersGuard = "request"
+	guardRequestNoCORS headersGuard = "request-no-cors"
+	guardResponse      headersGuard = "response"
+	guardNone          headersGuard = "none"
+)
+
+// https://fetch.spec.whatwg.org/#forbidden-request-header
+var forbiddenRequestHeaderNames = []string{
+	"Accept-Charset", "Accept-Encoding", "Access-Control-Request-Headers",
+	"Access-Control-Request-Method", "Connection", "Content-Length",
+	"Cookie", "Cookie2", "Date", "DNT", "Expect", "Host", "Keep-Alive",
+	"Origin", "Referer", "Set-Cookie", "Set-Cookie2", "TE", "Trailer",
+	"Transfer-Encoding", "Upgrade", "Via",
+}
+
+func isForbiddenRequestHeader(name string) bool {
+	lower := strings.ToLower(name)
+	if strings.HasPrefix(lower, "proxy-") || strings.HasPrefix(lower, "sec-") {
+		return true
 	}
-	if headerValue, err := newConceptHeaderValue([]byte(value)); err != nil {
-		return false, err
+	for _, forbidden := range forbiddenRequestHeaderNames {
+		if strings.EqualFold(name, forbidden) {
+			return true
+		}
 	}
-	if headers.guard == "immutable" {
-		return false, errors.New("guard is immutable")
+	return false
+}
+
+// https://fetch.spec.whatwg.org/#forbidden-response-header-name
+func isForbiddenResponseHeader(name string) bool {
+	return strings.EqualFold(name, "Set-Cookie") || strings.EqualFold(name, "Set-Cookie2")
+}
+
+// https://fetch.spec.whatwg.org/#concept-headers-validate
+func validate(name string, value *string, headers *Headers) (conceptHeaderName, conceptHeaderValue, error) {
+	headerName, err := newConceptHeaderName([]byte(name))
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid header name %q: %w", name, err)
 	}
-	if headers.guard == "request" && isForbiddenRequestHeader(struct{Name string;Value string}{name, value}) {
-		return false, errors.New("forbidden request header")
+
+	var headerValue conceptHeaderValue
+	if value != nil {
+		headerValue, err = newConceptHeaderValue(normalize([]byte(*value)))
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid header value for %q: %w", name, err)
+		}
 	}
+
+	switch headers.guard {
+	case guardImmutable:
+		return nil, nil, errors.New("headers are immutable")
+	case guardRequest:
+		if isForbiddenRequestHeader(name) {
+			return nil, nil, fmt.Errorf("%q is a forbidden request header", name)
+		}
+	case guardResponse:
+		if isForbiddenResponseHeader(name) {
+			return nil, nil, fmt.Errorf("%q is a forbidden response header", name)
+		}
+	}
+
+	return headerName, headerValue, nil
 }
 
-func NewHeaders[T HeadersInit](init T) *Headers {
-	return nil
+// https://fetch.spec.whatwg.org/#typedefdef-headersinit
+//
+// init may be nil, a sequence of name/value pairs ([][2]string or
+// [][]string), or a record ordered by key (map[string]string).
+//
+// https://fetch.spec.whatwg.org/#headers-class
+type Headers struct {
+	headerList headerList
+	guard      headersGuard
 }
 
-func (h *Headers) Append(name string, value string) {
-	h.Set(name, value)
+func NewHeaders(init any) (*Headers, error) {
+	h := &Headers{guard: guardNone}
+	if err := h.fill(init); err != nil {
+		return nil, err
+	}
+	return h, nil
 }
 
-func (h *Headers) Delete(name string) {
-	delete(h.headerList, name)
+// https://fetch.spec.whatwg.org/#concept-headers-fill
+func (h *Headers) fill(init any) error {
+	switch init := init.(type) {
+	case nil:
+		return nil
+	case map[string]string:
+		names := make([]string, 0, len(init))
+		for name := range init {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			if err := h.Append(name, init[name]); err != nil {
+				return err
+			}
+		}
+		return nil
+	case [][2]string:
+		for _, pair := range init {
+			if err := h.Append(pair[0], pair[1]); err != nil {
+				return err
+			}
+		}
+		return nil
+	case [][]string:
+		for _, pair := range init {
+			if len(pair) != 2 {
+				return fmt.Errorf("invalid header entry, expected a name/value pair: %#v", pair)
+			}
+			if err := h.Append(pair[0], pair[1]); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("invalid HeadersInit: %#v", init)
+	}
 }
 
-func (h *Headers) Get(name string) *string {
-	value, ok := h.headerList[name]
-	if ok {
-		return &value
-	} else {
+// newHeadersFromHTTP builds a response Headers, with guard "response", from
+// an http.Response's http.Header. It bypasses the guarded Append path so
+// that Set-Cookie headers are preserved (for GetSetCookie) even though
+// guardResponse otherwise forbids setting them.
+func newHeadersFromHTTP(h http.Header) (*Headers, error) {
+	headers := &Headers{guard: guardResponse}
+	for name, values := range h {
+		headerName, err := newConceptHeaderName([]byte(name))
+		if err != nil {
+			continue
+		}
+		for _, value := range values {
+			headerValue, err := newConceptHeaderValue(normalize([]byte(value)))
+			if err != nil {
+				continue
+			}
+			headers.headerList.append(headerName, headerValue)
+		}
+	}
+	return headers, nil
+}
+
+// https://fetch.spec.whatwg.org/#dom-headers-append
+func (h *Headers) Append(name string, value string) error {
+	headerName, headerValue, err := validate(name, &value, h)
+	if err != nil {
+		return err
+	}
+	h.headerList.append(headerName, headerValue)
+	return nil
+}
+
+// https://fetch.spec.whatwg.org/#dom-headers-delete
+func (h *Headers) Delete(name string) error {
+	if _, _, err := validate(name, nil, h); err != nil {
+		return err
+	}
+	if !h.headerList.contains(name) {
 		return nil
 	}
+	h.headerList.delete(name)
+	return nil
+}
+
+// https://fetch.spec.whatwg.org/#dom-headers-get
+func (h *Headers) Get(name string) (*string, error) {
+	if _, _, err := validate(name, nil, h); err != nil {
+		return nil, err
+	}
+	return h.headerList.get(name), nil
+}
+
+// https://fetch.spec.whatwg.org/#dom-headers-getsetcookie
+func (h *Headers) GetSetCookie() []string {
+	return h.headerList.getSetCookie()
+}
+
+// https://fetch.spec.whatwg.org/#dom-headers-has
+func (h *Headers) Has(name string) (bool, error) {
+	if _, _, err := validate(name, nil, h); err != nil {
+		return false, err
+	}
+	return h.headerList.contains(name), nil
+}
+
+// https://fetch.spec.whatwg.org/#dom-headers-set
+func (h *Headers) Set(name string, value string) error {
+	headerName, headerValue, err := validate(name, &value, h)
+	if err != nil {
+		return err
+	}
+	h.headerList.set(headerName, headerValue)
+	return nil
 }
 
-func (h *Headers) Set(name string, value string) {
-	h.headerList[name] = value
+// Keys returns the Headers' names, sorted and deduplicated as for iteration.
+//
+// https://fetch.spec.whatwg.org/#concept-header-list-sort-and-combine
+func (h *Headers) Keys() []string {
+	sorted := h.headerList.sortAndCombine()
+	keys := make([]string, len(sorted))
+	for i, header := range sorted {
+		keys[i] = string(header.Name)
+	}
+	return keys
+}
+
+// Values returns the Headers' combined values, in the same order as Keys.
+func (h *Headers) Values() []string {
+	sorted := h.headerList.sortAndCombine()
+	values := make([]string, len(sorted))
+	for i, header := range sorted {
+		values[i] = string(header.Value)
+	}
+	return values
 }
 
-func (h *Headers) Iterable() map[string]string {
-	iterable := map[string]string{}
-	for name, value := range h.headerList {
-		iterable[name] = value
+// Entries returns the Headers' name/value pairs, in the same order as Keys
+// and Values.
+func (h *Headers) Entries() [][2]string {
+	sorted := h.headerList.sortAndCombine()
+	entries := make([][2]string, len(sorted))
+	for i, header := range sorted {
+		entries[i] = [2]string{string(header.Name), string(header.Value)}
 	}
-	return iterable
+	return entries
 }
@@ -0,0 +1,82 @@
+package fetch
+
+import (
+	"github.com/jcbhmr/go-fetch/rfc8941"
+)
+
+// Structured parses name's header value as a Structured Field of fieldType
+// ("list", "dictionary", or "item"), per RFC 8941 Section 4.2, combining
+// multiple header instances with ", " before parsing (Get already does
+// this, excluding Set-Cookie). It returns nil if name is not present. This
+// is the generic entry point for extensions defining new Structured
+// Fields; GetList, GetDictionary, and GetItem are typed wrappers for the
+// three standard top-level shapes.
+func (h *Headers) Structured(name string, fieldType string) (rfc8941.StructuredFieldValue, error) {
+	value, err := h.Get(name)
+	if err != nil || value == nil {
+		return nil, err
+	}
+	return rfc8941.TextParse([]byte(*value), fieldType)
+}
+
+// GetList parses name as a Structured Field List (RFC 8941 Section 3.1),
+// e.g. Accept-CH or Client-Hints.
+func (h *Headers) GetList(name string) (rfc8941.List, error) {
+	value, err := h.Structured(name, "list")
+	if err != nil || value == nil {
+		return nil, err
+	}
+	return rfc8941.ListFromLegacy(value)
+}
+
+// GetDictionary parses name as a Structured Field Dictionary (RFC 8941
+// Section 3.2), e.g. Cache-Status or Signature-Input.
+func (h *Headers) GetDictionary(name string) (rfc8941.Dictionary, error) {
+	value, err := h.Structured(name, "dictionary")
+	if err != nil || value == nil {
+		return rfc8941.Dictionary{}, err
+	}
+	return rfc8941.DictionaryFromLegacy(value)
+}
+
+// GetItem parses name as a Structured Field Item (RFC 8941 Section 3.3),
+// e.g. Priority.
+func (h *Headers) GetItem(name string) (rfc8941.Item, error) {
+	value, err := h.Structured(name, "item")
+	if err != nil || value == nil {
+		return rfc8941.Item{}, err
+	}
+	return rfc8941.ItemFromLegacy(value)
+}
+
+// SetStructuredHeader serializes value -- a rfc8941.List, rfc8941.Dictionary,
+// or rfc8941.Item -- with rfc8941.TextSerialize (RFC 8941 Section 4) and
+// stores the result under name, returning an error if serialization fails.
+// It validates name/value and honors the Headers' guard exactly like Set;
+// this is the untyped counterpart to Structured, and SetList/SetDictionary/
+// SetItem are typed wrappers around it.
+func (h *Headers) SetStructuredHeader(name string, value rfc8941.StructuredFieldValue) error {
+	serialized, err := rfc8941.TextSerialize(value)
+	if err != nil {
+		return err
+	}
+	return h.Set(name, string(serialized))
+}
+
+// SetList serializes list with rfc8941.TextSerialize and sets it as name's
+// header value.
+func (h *Headers) SetList(name string, list rfc8941.List) error {
+	return h.SetStructuredHeader(name, list)
+}
+
+// SetDictionary serializes dictionary with rfc8941.TextSerialize and sets
+// it as name's header value.
+func (h *Headers) SetDictionary(name string, dictionary rfc8941.Dictionary) error {
+	return h.SetStructuredHeader(name, dictionary)
+}
+
+// SetItem serializes item with rfc8941.TextSerialize and sets it as name's
+// header value.
+func (h *Headers) SetItem(name string, item rfc8941.Item) error {
+	return h.SetStructuredHeader(name, item)
+}
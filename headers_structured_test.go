@@ -0,0 +1,85 @@
+package fetch
+
+import (
+	"testing"
+
+	"github.com/jcbhmr/go-fetch/rfc8941"
+)
+
+func TestSetStructuredHeaderRoundTripsItem(t *testing.T) {
+	h, err := NewHeaders(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	item, err := rfc8941.ItemFromLegacy(mustParseItem(t, "text/html;q=1.0"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := h.SetStructuredHeader("Accept", item); err != nil {
+		t.Fatal(err)
+	}
+
+	value, err := h.Get("Accept")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value == nil {
+		t.Fatal("expected a value")
+	}
+	if *value != "text/html;q=1.0" {
+		t.Errorf("Get(%q) = %q, want %q", "Accept", *value, "text/html;q=1.0")
+	}
+
+	roundTripped, err := h.GetItem("Accept")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if roundTripped.Value != item.Value {
+		t.Errorf("GetItem() = %#+v, want %#+v", roundTripped, item)
+	}
+}
+
+func TestSetItemMatchesSetStructuredHeader(t *testing.T) {
+	item, err := rfc8941.ItemFromLegacy(mustParseItem(t, "text/html;q=1.0"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	viaSetItem, err := NewHeaders(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := viaSetItem.SetItem("Accept", item); err != nil {
+		t.Fatal(err)
+	}
+
+	viaSetStructuredHeader, err := NewHeaders(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := viaSetStructuredHeader.SetStructuredHeader("Accept", item); err != nil {
+		t.Fatal(err)
+	}
+
+	a, err := viaSetItem.Get("Accept")
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := viaSetStructuredHeader.Get("Accept")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *a != *b {
+		t.Errorf("SetItem produced %q, SetStructuredHeader produced %q", *a, *b)
+	}
+}
+
+func mustParseItem(t *testing.T, s string) rfc8941.StructuredFieldValue {
+	t.Helper()
+	value, err := rfc8941.TextParse([]byte(s), "item")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return value
+}
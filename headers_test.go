@@ -0,0 +1,73 @@
+package fetch
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGetSetCookieReturnsEachEntrySeparately(t *testing.T) {
+	h, err := NewHeaders(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := h.Append("Set-Cookie", "a=1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := h.Append("Set-Cookie", "b=2"); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := h.GetSetCookie(), []string{"a=1", "b=2"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("GetSetCookie() = %#v, want %#v", got, want)
+	}
+
+	// Get combines other headers with ", ", but for Set-Cookie that would
+	// produce a string that can't be parsed back into individual
+	// cookies, so it returns only the first entry.
+	value, err := h.Get("Set-Cookie")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value == nil || *value != "a=1" {
+		t.Errorf("Get(%q) = %v, want %q", "Set-Cookie", value, "a=1")
+	}
+}
+
+func TestEntriesCombinesDuplicatesButPreservesSetCookie(t *testing.T) {
+	h, err := NewHeaders(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := h.Append("Content-Type", "text/html"); err != nil {
+		t.Fatal(err)
+	}
+	if err := h.Append("Content-Type", "text/plain"); err != nil {
+		t.Fatal(err)
+	}
+	if err := h.Append("Set-Cookie", "a=1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := h.Append("Set-Cookie", "b=2"); err != nil {
+		t.Fatal(err)
+	}
+	if err := h.Append("Accept", "text/html"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Iteration is case-insensitively sorted by name: "accept", then
+	// "content-type", then "set-cookie" (each occurrence separate).
+	wantKeys := []string{"accept", "content-type", "set-cookie", "set-cookie"}
+	if got := h.Keys(); !reflect.DeepEqual(got, wantKeys) {
+		t.Errorf("Keys() = %#v, want %#v", got, wantKeys)
+	}
+
+	wantEntries := [][2]string{
+		{"accept", "text/html"},
+		{"content-type", "text/html, text/plain"},
+		{"set-cookie", "a=1"},
+		{"set-cookie", "b=2"},
+	}
+	if got := h.Entries(); !reflect.DeepEqual(got, wantEntries) {
+		t.Errorf("Entries() = %#v, want %#v", got, wantEntries)
+	}
+}
@@ -0,0 +1,113 @@
+// Package headless implements a fetch.Client backed by a headless Chrome
+// instance via chromedp, for fetching pages that need JavaScript execution
+// to render — e.g. single-page apps, where the plain net/http-backed
+// client only ever sees the initial empty HTML shell.
+package headless
+
+import (
+	"fmt"
+
+	"github.com/chromedp/cdproto/network"
+	"github.com/chromedp/chromedp"
+
+	"github.com/jcbhmr/go-fetch"
+)
+
+// ClientID identifies Client.
+const ClientID fetch.ClientID = "headless-chrome"
+
+// Client is a fetch.Client that navigates a headless Chrome instance to
+// the request URL, waits for the page to finish loading, and returns the
+// fully-rendered document (after any client-side JavaScript has run) as
+// the Response body.
+//
+// Like a browser's address bar, Chrome navigation has no way to attach a
+// request body, so Client only supports GET requests; Do returns an error
+// for any other method or for a request with a body.
+type Client struct {
+	// AllocatorOptions are appended to chromedp.DefaultExecAllocatorOptions
+	// when starting the browser, e.g. to point at a specific Chrome binary
+	// or run headless in a container.
+	AllocatorOptions []chromedp.ExecAllocatorOption
+}
+
+// NewClient returns a Client using chromedp's default allocator options.
+func NewClient() *Client {
+	return &Client{}
+}
+
+func (c *Client) Identifier() fetch.ClientID {
+	return ClientID
+}
+
+func (c *Client) Do(req *fetch.Request) (*fetch.Response, error) {
+	if method := req.Method(); method != "" && method != "GET" {
+		return nil, fmt.Errorf("headless: %s not supported, Chrome navigation has no request body", method)
+	}
+	if req.Body() != nil {
+		return nil, fmt.Errorf("headless: request bodies are not supported by Chrome navigation")
+	}
+
+	allocOpts := append(append([]chromedp.ExecAllocatorOption{}, chromedp.DefaultExecAllocatorOptions[:]...), c.AllocatorOptions...)
+	allocCtx, cancelAlloc := chromedp.NewExecAllocator(req.Context(), allocOpts...)
+	defer cancelAlloc()
+
+	ctx, cancelCtx := chromedp.NewContext(allocCtx)
+	defer cancelCtx()
+
+	headerEntries := req.Headers().Entries()
+	extraHeaders := make(network.Headers, len(headerEntries))
+	for _, entry := range headerEntries {
+		extraHeaders[entry[0]] = entry[1]
+	}
+
+	var (
+		status          int64
+		statusText      string
+		respURL         string
+		respHeaderPairs [][2]string
+	)
+	chromedp.ListenTarget(ctx, func(ev any) {
+		resp, ok := ev.(*network.EventResponseReceived)
+		if !ok || resp.Type != network.ResourceTypeDocument || status != 0 {
+			return
+		}
+		status = resp.Response.Status
+		statusText = resp.Response.StatusText
+		respURL = resp.Response.URL
+		for name, value := range resp.Response.Headers {
+			if s, ok := value.(string); ok {
+				respHeaderPairs = append(respHeaderPairs, [2]string{name, s})
+			}
+		}
+	})
+
+	var html string
+	err := chromedp.Run(ctx,
+		network.Enable(),
+		network.SetExtraHTTPHeaders(extraHeaders),
+		chromedp.Navigate(req.URL().String()),
+		chromedp.OuterHTML("html", &html, chromedp.ByQuery),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("headless: %w", err)
+	}
+
+	if status == 0 {
+		status, statusText, respURL = 200, "OK", req.URL().String()
+	}
+	headers, err := fetch.NewHeaders(respHeaderPairs)
+	if err != nil {
+		return nil, fmt.Errorf("headless: building response headers: %w", err)
+	}
+
+	return &fetch.Response{
+		Status:     int(status),
+		StatusText: statusText,
+		Headers:    headers,
+		URL:        respURL,
+		Redirected: respURL != req.URL().String(),
+		Type:       "basic",
+		Body:       fetch.NewBody([]byte(html)),
+	}, nil
+}
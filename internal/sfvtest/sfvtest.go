@@ -0,0 +1,158 @@
+// Package sfvtest loads and runs the shared JSON conformance corpus
+// published by https://github.com/httpwg/structured-field-tests, which
+// several Structured Field Values implementations (this module, the Rust
+// `sfv` crate, the Erlang `cow_http_struct_hd` library, ...) run against
+// to check interoperability.
+package sfvtest
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/jcbhmr/go-fetch/rfc8941"
+)
+
+// Case mirrors one entry of the upstream corpus.
+type Case struct {
+	Name       string          `json:"name"`
+	Raw        []string        `json:"raw"`
+	HeaderType string          `json:"header_type"`
+	Expected   json.RawMessage `json:"expected,omitempty"`
+	Canonical  []string        `json:"canonical,omitempty"`
+	MustFail   bool            `json:"must_fail,omitempty"`
+	CanFail    bool            `json:"can_fail,omitempty"`
+}
+
+// Load reads and decodes the corpus file dir/name.
+func Load(dir, name string) ([]Case, error) {
+	data, err := os.ReadFile(filepath.Join(dir, name))
+	if err != nil {
+		return nil, err
+	}
+	var cases []Case
+	if err := json.Unmarshal(data, &cases); err != nil {
+		return nil, fmt.Errorf("sfvtest: decoding %s: %w", name, err)
+	}
+	return cases, nil
+}
+
+// Save writes cases back to dir/name, the way -update flags in the
+// packages that use this harness regenerate golden canonical outputs
+// after recomputing them.
+func Save(dir, name string, cases []Case) error {
+	data, err := json.MarshalIndent(cases, "", "  ")
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+	return os.WriteFile(filepath.Join(dir, name), data, 0644)
+}
+
+// JoinRaw concatenates a case's raw field lines the way multiple instances
+// of the same HTTP field are combined into one field value before parsing
+// (RFC 8941 Section 3.2 "Parsing a List/Dictionary from Several Field
+// Lines"): with ", " between each line.
+func JoinRaw(lines []string) []byte {
+	raw := []byte(lines[0])
+	for _, line := range lines[1:] {
+		raw = append(raw, ", "+line...)
+	}
+	return raw
+}
+
+// Comparable converts a value produced by this module's codec — List,
+// Dictionary, Item, InnerList, BareItem, Parameters, or a legacy
+// any-shaped bare item (int64, float64, string, rfc8941.Token, []byte,
+// bool) — into the generic map/slice/float64/string/bool shape produced
+// by encoding/json, using the corpus's `{"__type": "token"|"binary",
+// "value": ...}` convention for the bare item types JSON cannot represent
+// natively.
+func Comparable(v any) any {
+	switch v := v.(type) {
+	case int64:
+		return float64(v)
+	case rfc8941.Integer:
+		return float64(v)
+	case float64:
+		return v
+	case rfc8941.Decimal:
+		return float64(v)
+	case string:
+		return v
+	case rfc8941.String:
+		return string(v)
+	case bool:
+		return v
+	case rfc8941.Boolean:
+		return bool(v)
+	case []byte:
+		return map[string]any{"__type": "binary", "value": base64.StdEncoding.EncodeToString(v)}
+	case rfc8941.ByteSeq:
+		return map[string]any{"__type": "binary", "value": base64.StdEncoding.EncodeToString([]byte(v))}
+	case rfc8941.Token:
+		return map[string]any{"__type": "token", "value": string(v)}
+	case rfc8941.Date:
+		return map[string]any{"__type": "date", "value": float64(v)}
+	case rfc8941.DisplayString:
+		return map[string]any{"__type": "displaystring", "value": string(v)}
+	case rfc8941.Parameters:
+		out := map[string]any{}
+		for _, p := range v {
+			out[p.V1] = Comparable(p.V2)
+		}
+		return out
+	case rfc8941.Item:
+		return []any{Comparable(v.Value), Comparable(v.Parameters)}
+	case rfc8941.InnerList:
+		items := make([]any, len(v.Items))
+		for i, it := range v.Items {
+			items[i] = []any{Comparable(it.Value), Comparable(it.Parameters)}
+		}
+		return []any{items, Comparable(v.Parameters)}
+	case rfc8941.List:
+		members := make([]any, len(v))
+		for i, m := range v {
+			members[i] = Comparable(m)
+		}
+		return members
+	case rfc8941.Dictionary:
+		keys := v.Keys()
+		out := make([]any, len(keys))
+		for i, key := range keys {
+			member, _ := v.Get(key)
+			out[i] = []any{key, Comparable(member)}
+		}
+		return out
+	default:
+		return v
+	}
+}
+
+// EqualExpected decodes a case's Expected field and reports whether it is
+// deep-equal to got, after converting got with Comparable and round
+// tripping both values through encoding/json so map/slice representations
+// match exactly.
+func EqualExpected(c Case, got any) (ok bool, gotJSON, wantJSON []byte, err error) {
+	gotJSON, err = json.Marshal(Comparable(got))
+	if err != nil {
+		return false, nil, nil, err
+	}
+	var gotAny, wantAny any
+	if err := json.Unmarshal(gotJSON, &gotAny); err != nil {
+		return false, nil, nil, err
+	}
+	if err := json.Unmarshal(c.Expected, &wantAny); err != nil {
+		return false, nil, nil, err
+	}
+	wantJSON, _ = json.Marshal(wantAny)
+	return jsonEqual(gotAny, wantAny), gotJSON, wantJSON, nil
+}
+
+func jsonEqual(a, b any) bool {
+	aj, _ := json.Marshal(a)
+	bj, _ := json.Marshal(b)
+	return string(aj) == string(bj)
+}
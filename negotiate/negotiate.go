@@ -0,0 +1,279 @@
+// Package negotiate implements quality-based content negotiation (RFC
+// 9110 Section 12) over the Accept, Accept-Encoding, Accept-Language,
+// and Accept-Charset request headers. Each header is parsed with
+// *Headers.GetList -- fetch's existing RFC 8941 Structured Fields
+// support -- since a comma-separated Accept* range with optional
+// ";q=..." parameters is already a valid SF List of Items, so no
+// separate comma-splitting grammar is needed for the common case. Only
+// legacy values that aren't a strict SF List (e.g. a quoted q="0.8")
+// fall back to a permissive split.
+package negotiate
+
+import (
+	"strconv"
+	"strings"
+
+	fetch "github.com/jcbhmr/go-fetch"
+	"github.com/jcbhmr/go-fetch/rfc8941"
+)
+
+// weightedRange is one comma-separated entry of an Accept* header: a
+// value (e.g. "text/html", "gzip", or "en-US") together with its "q"
+// weight.
+type weightedRange struct {
+	value string
+	q     float64
+}
+
+// parseRanges reads name (e.g. "Accept") from h and splits it into its
+// weighted ranges, preferring the already-available SF List parse over
+// a bespoke grammar.
+func parseRanges(h *fetch.Headers, name string) ([]weightedRange, error) {
+	list, err := h.GetList(name)
+	if err == nil {
+		return rangesFromList(list), nil
+	}
+
+	raw, err := h.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	if raw == nil {
+		return nil, nil
+	}
+	return rangesFromLegacy(*raw), nil
+}
+
+func rangesFromList(list rfc8941.List) []weightedRange {
+	ranges := make([]weightedRange, 0, len(list))
+	for _, member := range list {
+		item, ok := member.(rfc8941.Item)
+		if !ok {
+			// Accept* headers never carry Inner Lists; skip one rather
+			// than failing the whole header.
+			continue
+		}
+		value, ok := bareItemString(item.Value)
+		if !ok {
+			continue
+		}
+		ranges = append(ranges, weightedRange{value: value, q: itemQuality(item)})
+	}
+	return ranges
+}
+
+func bareItemString(v rfc8941.BareItem) (string, bool) {
+	switch v := v.(type) {
+	case rfc8941.Token:
+		return string(v), true
+	case rfc8941.String:
+		return string(v), true
+	default:
+		return "", false
+	}
+}
+
+// itemQuality reads item's "q" parameter, defaulting to 1.0 and
+// clamping to [0, 1] (RFC 9110 Section 12.4.2).
+func itemQuality(item rfc8941.Item) float64 {
+	for _, p := range item.Parameters {
+		if p.V1 != "q" {
+			continue
+		}
+		switch v := p.V2.(type) {
+		case float64:
+			return clampQuality(v)
+		case int64:
+			return clampQuality(float64(v))
+		}
+	}
+	return 1.0
+}
+
+// rangesFromLegacy parses raw with the legacy "value;q=..., value;..."
+// grammar, for Accept* values that don't parse as a strict SF List.
+func rangesFromLegacy(raw string) []weightedRange {
+	var ranges []weightedRange
+	for _, part := range strings.Split(raw, ",") {
+		fields := strings.Split(part, ";")
+		value := strings.TrimSpace(fields[0])
+		if value == "" {
+			continue
+		}
+
+		q := 1.0
+		for _, field := range fields[1:] {
+			name, val, ok := strings.Cut(strings.TrimSpace(field), "=")
+			if !ok || strings.TrimSpace(name) != "q" {
+				continue
+			}
+			val = strings.Trim(strings.TrimSpace(val), `"`)
+			if parsed, err := strconv.ParseFloat(val, 64); err == nil {
+				q = parsed
+			}
+		}
+		ranges = append(ranges, weightedRange{value: value, q: clampQuality(q)})
+	}
+	return ranges
+}
+
+func clampQuality(q float64) float64 {
+	if q < 0 {
+		return 0
+	}
+	if q > 1 {
+		return 1
+	}
+	return q
+}
+
+// matchFunc reports whether rangeValue (one weighted range from the
+// Accept* header) accepts offer, and if so how specific the match was --
+// higher wins ties against a less specific match at the same q.
+type matchFunc func(rangeValue, offer string) (matched bool, specificity int)
+
+// bestMatch returns the offer with the highest q among ranges, per
+// matchFunc, breaking ties in favor of the more specific match. If
+// ranges is empty (the header was absent), no preference was expressed,
+// so the first offer is returned with a weight of 1.
+func bestMatch(ranges []weightedRange, offers []string, match matchFunc) (string, float64) {
+	if len(ranges) == 0 {
+		if len(offers) == 0 {
+			return "", 0
+		}
+		return offers[0], 1
+	}
+
+	bestOffer := ""
+	bestQ := -1.0
+	bestSpecificity := -1
+	for _, offer := range offers {
+		matched := false
+		var q float64
+		var specificity int
+		for _, r := range ranges {
+			ok, spec := match(r.value, offer)
+			if !ok {
+				continue
+			}
+			if !matched || spec > specificity {
+				matched, q, specificity = true, r.q, spec
+			}
+		}
+		if !matched || q <= 0 {
+			continue
+		}
+		if q > bestQ || (q == bestQ && specificity > bestSpecificity) {
+			bestOffer, bestQ, bestSpecificity = offer, q, specificity
+		}
+	}
+	if bestOffer == "" {
+		return "", 0
+	}
+	return bestOffer, bestQ
+}
+
+// mediaTypeMatch implements Accept's wildcard rules (RFC 9110 Section
+// 12.5.1): an exact "type/subtype" match is more specific than
+// "type/*", which is more specific than "*/*".
+func mediaTypeMatch(rangeValue, offer string) (bool, int) {
+	rangeValue = strings.ToLower(rangeValue)
+	offer = strings.ToLower(offer)
+	if rangeValue == offer {
+		return true, 2
+	}
+
+	rType, rSubtype, ok := strings.Cut(rangeValue, "/")
+	if !ok {
+		return false, 0
+	}
+	oType, _, ok := strings.Cut(offer, "/")
+	if !ok {
+		return false, 0
+	}
+
+	if rType == "*" && rSubtype == "*" {
+		return true, 0
+	}
+	if rSubtype == "*" && rType == oType {
+		return true, 1
+	}
+	return false, 0
+}
+
+// tokenMatch implements the Accept-Encoding and Accept-Charset wildcard
+// rule (RFC 9110 Sections 12.5.2 and 12.5.3): "*" matches anything not
+// otherwise mentioned, an exact case-insensitive token match is more
+// specific.
+func tokenMatch(rangeValue, offer string) (bool, int) {
+	if rangeValue == "*" {
+		return true, 0
+	}
+	if strings.EqualFold(rangeValue, offer) {
+		return true, 1
+	}
+	return false, 0
+}
+
+// languageMatch implements Accept-Language's BCP 47 basic filtering
+// (RFC 9110 Section 12.5.4, RFC 4647 Section 3.3.1): a range of "en"
+// matches an offer of "en-US", and "*" matches any offer. A match on
+// more subtags is more specific.
+func languageMatch(rangeValue, offer string) (bool, int) {
+	if rangeValue == "*" {
+		return true, 0
+	}
+	if strings.EqualFold(rangeValue, offer) {
+		return true, len(strings.Split(offer, "-"))
+	}
+	prefix := rangeValue + "-"
+	if len(offer) > len(prefix) && strings.EqualFold(offer[:len(prefix)], prefix) {
+		return true, len(strings.Split(rangeValue, "-"))
+	}
+	return false, 0
+}
+
+// NegotiateContentType parses h's Accept header and returns the
+// highest-quality offer from offers (media types like "text/html"),
+// honoring "type/*" and "*/*" wildcards, along with its q value. If
+// Accept is absent, no preference was expressed, so the first offer is
+// returned with a weight of 1. If offers is empty, or none of them are
+// acceptable, it returns ("", 0).
+func NegotiateContentType(h *fetch.Headers, offers []string) (string, float64) {
+	ranges, err := parseRanges(h, "Accept")
+	if err != nil {
+		return "", 0
+	}
+	return bestMatch(ranges, offers, mediaTypeMatch)
+}
+
+// NegotiateEncoding is NegotiateContentType for the Accept-Encoding
+// header, matching offers like "gzip", "br", or "identity".
+func NegotiateEncoding(h *fetch.Headers, offers []string) (string, float64) {
+	ranges, err := parseRanges(h, "Accept-Encoding")
+	if err != nil {
+		return "", 0
+	}
+	return bestMatch(ranges, offers, tokenMatch)
+}
+
+// NegotiateLanguage is NegotiateContentType for the Accept-Language
+// header, matching offers like "en-US" against language ranges using
+// BCP 47 prefix matching.
+func NegotiateLanguage(h *fetch.Headers, offers []string) (string, float64) {
+	ranges, err := parseRanges(h, "Accept-Language")
+	if err != nil {
+		return "", 0
+	}
+	return bestMatch(ranges, offers, languageMatch)
+}
+
+// NegotiateCharset is NegotiateContentType for the Accept-Charset
+// header, matching offers like "utf-8".
+func NegotiateCharset(h *fetch.Headers, offers []string) (string, float64) {
+	ranges, err := parseRanges(h, "Accept-Charset")
+	if err != nil {
+		return "", 0
+	}
+	return bestMatch(ranges, offers, tokenMatch)
+}
@@ -0,0 +1,89 @@
+package negotiate
+
+import (
+	"testing"
+
+	fetch "github.com/jcbhmr/go-fetch"
+)
+
+func headersWith(t *testing.T, name, value string) *fetch.Headers {
+	t.Helper()
+	h, err := fetch.NewHeaders(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := h.Set(name, value); err != nil {
+		t.Fatal(err)
+	}
+	return h
+}
+
+func TestNegotiateContentType(t *testing.T) {
+	h := headersWith(t, "Accept", "text/html;q=0.8, application/json, */*;q=0.1")
+	offer, q := NegotiateContentType(h, []string{"text/html", "application/json"})
+	if offer != "application/json" || q != 1 {
+		t.Errorf("NegotiateContentType() = %q, %v, want %q, 1", offer, q, "application/json")
+	}
+}
+
+func TestNegotiateContentTypeWildcard(t *testing.T) {
+	h := headersWith(t, "Accept", "text/*;q=0.5, */*;q=0.1")
+	offer, q := NegotiateContentType(h, []string{"application/json", "text/plain"})
+	if offer != "text/plain" || q != 0.5 {
+		t.Errorf("NegotiateContentType() = %q, %v, want %q, 0.5", offer, q, "text/plain")
+	}
+}
+
+func TestNegotiateContentTypeRejectsQZero(t *testing.T) {
+	h := headersWith(t, "Accept", "text/html;q=0, */*")
+	offer, q := NegotiateContentType(h, []string{"text/html"})
+	if offer != "" || q != 0 {
+		t.Errorf("NegotiateContentType() = %q, %v, want \"\", 0", offer, q)
+	}
+}
+
+func TestNegotiateContentTypeNoHeader(t *testing.T) {
+	h, err := fetch.NewHeaders(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	offer, q := NegotiateContentType(h, []string{"text/html", "application/json"})
+	if offer != "text/html" || q != 1 {
+		t.Errorf("NegotiateContentType() = %q, %v, want %q, 1", offer, q, "text/html")
+	}
+}
+
+func TestNegotiateEncoding(t *testing.T) {
+	h := headersWith(t, "Accept-Encoding", "gzip;q=0.5, br, identity;q=0")
+	offer, q := NegotiateEncoding(h, []string{"identity", "gzip", "br"})
+	if offer != "br" || q != 1 {
+		t.Errorf("NegotiateEncoding() = %q, %v, want %q, 1", offer, q, "br")
+	}
+}
+
+func TestNegotiateLanguagePrefixMatch(t *testing.T) {
+	h := headersWith(t, "Accept-Language", "en;q=0.5, fr-CA;q=0.9, *;q=0.1")
+	offer, q := NegotiateLanguage(h, []string{"en-US", "fr-CA"})
+	if offer != "fr-CA" || q != 0.9 {
+		t.Errorf("NegotiateLanguage() = %q, %v, want %q, 0.9", offer, q, "fr-CA")
+	}
+}
+
+func TestNegotiateCharset(t *testing.T) {
+	h := headersWith(t, "Accept-Charset", "iso-8859-1;q=0.3, utf-8;q=0.9")
+	offer, q := NegotiateCharset(h, []string{"iso-8859-1", "utf-8"})
+	if offer != "utf-8" || q != 0.9 {
+		t.Errorf("NegotiateCharset() = %q, %v, want %q, 0.9", offer, q, "utf-8")
+	}
+}
+
+func TestNegotiateContentTypeLegacyGrammarFallback(t *testing.T) {
+	// A leading-dot decimal like ".9" isn't a valid SF number (Section
+	// 3.3.2 requires at least one leading digit), so GetList fails to
+	// parse the header and parseRanges falls back to the legacy grammar.
+	h := headersWith(t, "Accept", "text/html;q=.5, application/json;q=.9")
+	offer, q := NegotiateContentType(h, []string{"text/html", "application/json"})
+	if offer != "application/json" || q != 0.9 {
+		t.Errorf("NegotiateContentType() = %q, %v, want %q, 0.9", offer, q, "application/json")
+	}
+}
@@ -0,0 +1,86 @@
+// go-fetch-specific code related to RFC 5234. This is not a complete implementation of RFC 5234.
+package rfc5234
+
+/*
+# B.1.  Core Rules
+
+Byte predicates for the single-character core rules in Appendix B.1,
+backed by direct range/bit checks instead of a compiled regexp matched
+against a one-character string. Use these instead of the regexp variables
+above in anything performance-sensitive, such as per-character loops in a
+serializer or parser.
+
+https://www.rfc-editor.org/rfc/rfc5234.html#appendix-B.1
+*/
+
+// IsALPHA reports whether b is ALPHA (%x41-5A / %x61-7A; A-Z / a-z).
+func IsALPHA(b byte) bool {
+	return (b >= 'A' && b <= 'Z') || (b >= 'a' && b <= 'z')
+}
+
+// IsBIT reports whether b is BIT ("0" / "1").
+func IsBIT(b byte) bool {
+	return b == '0' || b == '1'
+}
+
+// IsCHAR reports whether b is CHAR (%x01-7F; any 7-bit US-ASCII character,
+// excluding NUL).
+func IsCHAR(b byte) bool {
+	return b >= 0x01 && b <= 0x7F
+}
+
+// IsCR reports whether b is CR (%x0D; carriage return).
+func IsCR(b byte) bool {
+	return b == 0x0D
+}
+
+// IsCTL reports whether b is CTL (%x00-1F / %x7F; controls).
+func IsCTL(b byte) bool {
+	return b <= 0x1F || b == 0x7F
+}
+
+// IsDIGIT reports whether b is DIGIT (%x30-39; 0-9).
+func IsDIGIT(b byte) bool {
+	return b >= '0' && b <= '9'
+}
+
+// IsDQUOTE reports whether b is DQUOTE (%x22; ").
+func IsDQUOTE(b byte) bool {
+	return b == '"'
+}
+
+// IsHEXDIG reports whether b is HEXDIG (DIGIT / "A" / "B" / "C" / "D" / "E" / "F").
+func IsHEXDIG(b byte) bool {
+	return IsDIGIT(b) || (b >= 'A' && b <= 'F')
+}
+
+// IsHTAB reports whether b is HTAB (%x09; horizontal tab).
+func IsHTAB(b byte) bool {
+	return b == 0x09
+}
+
+// IsLF reports whether b is LF (%x0A; linefeed).
+func IsLF(b byte) bool {
+	return b == 0x0A
+}
+
+// IsOCTET reports whether b is OCTET (%x00-FF; 8 bits of data). Every byte
+// value satisfies this; it exists for parity with the other rules.
+func IsOCTET(b byte) bool {
+	return true
+}
+
+// IsSP reports whether b is SP (%x20).
+func IsSP(b byte) bool {
+	return b == 0x20
+}
+
+// IsVCHAR reports whether b is VCHAR (%x21-7E; visible (printing) characters).
+func IsVCHAR(b byte) bool {
+	return b >= 0x21 && b <= 0x7E
+}
+
+// IsWSP reports whether b is WSP (SP / HTAB; white space).
+func IsWSP(b byte) bool {
+	return b == 0x20 || b == 0x09
+}
@@ -15,11 +15,13 @@ https://www.rfc-editor.org/rfc/rfc5234.html#appendix-B.1
 // ALPHA          =  %x41-5A / %x61-7A   ; A-Z / a-z
 //
 // https://www.rfc-editor.org/rfc/rfc5234.html#appendix-B.1
+// Deprecated: use IsALPHA instead.
 var ALPHA = regexp.MustCompile(`^[A-Za-z]$`)
 
 // BIT            =  "0" / "1"
 //
 // https://www.rfc-editor.org/rfc/rfc5234.html#appendix-B.1
+// Deprecated: use IsBIT instead.
 var BIT = regexp.MustCompile(`^[01]$`)
 
 // CHAR           =  %x01-7F
@@ -28,6 +30,7 @@ var BIT = regexp.MustCompile(`^[01]$`)
 //	;  excluding NUL
 //
 // https://www.rfc-editor.org/rfc/rfc5234.html#appendix-B.1
+// Deprecated: use IsCHAR instead.
 var CHAR = regexp.MustCompile(`^[\x01-\x7F]$`)
 
 // CR             =  %x0D
@@ -35,6 +38,7 @@ var CHAR = regexp.MustCompile(`^[\x01-\x7F]$`)
 //	; carriage return
 //
 // https://www.rfc-editor.org/rfc/rfc5234.html#appendix-B.1
+// Deprecated: use IsCR instead.
 var CR = regexp.MustCompile(`^\x0D$`)
 
 // CRLF           =  CR LF
@@ -49,6 +53,7 @@ var CRLF = regexp.MustCompile(`^\x0D\x0A$`)
 //	; controls
 //
 // https://www.rfc-editor.org/rfc/rfc5234.html#appendix-B.1
+// Deprecated: use IsCTL instead.
 var CTL = regexp.MustCompile(`^[\x00-\x1F\x7F]$`)
 
 // DIGIT          =  %x30-39
@@ -56,6 +61,7 @@ var CTL = regexp.MustCompile(`^[\x00-\x1F\x7F]$`)
 //	; 0-9
 //
 // https://www.rfc-editor.org/rfc/rfc5234.html#appendix-B.1
+// Deprecated: use IsDIGIT instead.
 var DIGIT = regexp.MustCompile(`^\d$`)
 
 // DQUOTE         =  %x22
@@ -63,11 +69,13 @@ var DIGIT = regexp.MustCompile(`^\d$`)
 //	; " (Double Quote)
 //
 // https://www.rfc-editor.org/rfc/rfc5234.html#appendix-B.1
+// Deprecated: use IsDQUOTE instead.
 var DQUOTE = regexp.MustCompile(`^"$`)
 
 // HEXDIG         =  DIGIT / "A" / "B" / "C" / "D" / "E" / "F"
 //
 // https://www.rfc-editor.org/rfc/rfc5234.html#appendix-B.1
+// Deprecated: use IsHEXDIG instead.
 var HEXDIG = regexp.MustCompile(`^[\dA-F]$`)
 
 // HTAB           =  %x09
@@ -75,6 +83,7 @@ var HEXDIG = regexp.MustCompile(`^[\dA-F]$`)
 //	; horizontal tab
 //
 // https://www.rfc-editor.org/rfc/rfc5234.html#appendix-B.1
+// Deprecated: use IsHTAB instead.
 var HTAB = regexp.MustCompile(`^\x09$`)
 
 // LF             =  %x0A
@@ -82,6 +91,7 @@ var HTAB = regexp.MustCompile(`^\x09$`)
 //	; linefeed
 //
 // https://www.rfc-editor.org/rfc/rfc5234.html#appendix-B.1
+// Deprecated: use IsLF instead.
 var LF = regexp.MustCompile(`^\x0A$`)
 
 // LWSP           =  *(WSP / CRLF WSP)
@@ -104,11 +114,13 @@ var LWSP = regexp.MustCompile(`^(\s|\x0D\x0A\s)*$`)
 //	; 8 bits of data
 //
 // https://www.rfc-editor.org/rfc/rfc5234.html#appendix-B.1
+// Deprecated: use IsOCTET instead.
 var OCTET = regexp.MustCompile(`^[\x00-\xFF]$`)
 
 // SP             =  %x20
 //
 // https://www.rfc-editor.org/rfc/rfc5234.html#appendix-B.1
+// Deprecated: use IsSP instead.
 var SP = regexp.MustCompile(`^\x20$`)
 
 // VCHAR          =  %x21-7E
@@ -116,6 +128,7 @@ var SP = regexp.MustCompile(`^\x20$`)
 //	; visible (printing) characters
 //
 // https://www.rfc-editor.org/rfc/rfc5234.html#appendix-B.1
+// Deprecated: use IsVCHAR instead.
 var VCHAR = regexp.MustCompile(`^[\x21-\x7E]$`)
 
 // WSP            =  SP / HTAB
@@ -123,4 +136,5 @@ var VCHAR = regexp.MustCompile(`^[\x21-\x7E]$`)
 //	; white space
 //
 // https://www.rfc-editor.org/rfc/rfc5234.html#appendix-B.1
+// Deprecated: use IsWSP instead.
 var WSP = regexp.MustCompile(`^[\x20\x09]$`)
@@ -1,6 +1,10 @@
 package rfc7230
 
-import "regexp"
+import (
+	"regexp"
+
+	"github.com/jcbhmr/go-fetch/rfc5234"
+)
 
 /*
 # 3.2.3.  Whitespace
@@ -57,35 +61,49 @@ from the set of US-ASCII visual characters not allowed in a token
 https://www.rfc-editor.org/rfc/rfc7230.html#section-3.2.6
 */
 
-// 	token          = 1*tchar
+// token          = 1*tchar
 var Token = regexp.MustCompile(`^[!#$%&'*+\-.^_` + "`" + `|~0-9A-Za-z]+$`)
 
-// 	tchar          = "!" / "#" / "$" / "%" / "&" / "'" / "*"
-// 				/ "+" / "-" / "." / "^" / "_" / "`" / "|" / "~"
-// 				/ DIGIT / ALPHA
-// 				; any VCHAR, except delimiters
+// tchar          = "!" / "#" / "$" / "%" / "&" / "'" / "*"
+//
+//	/ "+" / "-" / "." / "^" / "_" / "`" / "|" / "~"
+//	/ DIGIT / ALPHA
+//	; any VCHAR, except delimiters
+//
+// Deprecated: use IsTChar instead.
 var TChar = regexp.MustCompile(`^[!#$%&'*+\-.^_` + "`" + `|~0-9A-Za-z]$`)
 
+// IsTChar reports whether b is tchar: "!" / "#" / "$" / "%" / "&" / "'" /
+// "*" / "+" / "-" / "." / "^" / "_" / "`" / "|" / "~" / DIGIT / ALPHA (any
+// VCHAR except delimiters).
+func IsTChar(b byte) bool {
+	switch b {
+	case '!', '#', '$', '%', '&', '\'', '*', '+', '-', '.', '^', '_', '`', '|', '~':
+		return true
+	}
+	return rfc5234.IsDIGIT(b) || rfc5234.IsALPHA(b)
+}
+
 // A string of text is parsed as a single value if it is quoted using
 // double-quote marks.
 //
-// 	quoted-string  = DQUOTE *( qdtext / quoted-pair ) DQUOTE
+//	quoted-string  = DQUOTE *( qdtext / quoted-pair ) DQUOTE
 var QuotedString = regexp.MustCompile(`^"[ \t\x21\x23-\x5B\x5D-\x7E\x80-\xFF]*"$`)
 
-// 	qdtext         = HTAB / SP /%x21 / %x23-5B / %x5D-7E / obs-text
+// qdtext         = HTAB / SP /%x21 / %x23-5B / %x5D-7E / obs-text
 var QDText = regexp.MustCompile(`^[\x09\x20\x21\x23-\x5B\x5D-\x7E\x80-\xFF]$`)
 
-// 	obs-text       = %x80-FF
+// obs-text       = %x80-FF
 var ObsText = regexp.MustCompile(`^[\x80-\xFF]$`)
 
 // Comments can be included in some HTTP header fields by surrounding
 // the comment text with parentheses.  Comments are only allowed in
 // fields containing "comment" as part of their field value definition.
 //
-// 	comment        = "(" *( ctext / quoted-pair / comment ) ")"
+//	comment        = "(" *( ctext / quoted-pair / comment ) ")"
 var Comment = regexp.MustCompile(`^\([ \t\x21-\x27\x2A-\x5B\x5D-\x7E\x80-\xFF]*\)$`)
 
-// 	ctext          = HTAB / SP / %x21-27 / %x2A-5B / %x5D-7E / obs-text
+// ctext          = HTAB / SP / %x21-27 / %x2A-5B / %x5D-7E / obs-text
 var CText = regexp.MustCompile(`^[\x09\x20\x21-\x27\x2A-\x5B\x5D-\x7E\x80-\xFF]$`)
 
 // The backslash octet ("\") can be used as a single-octet quoting
@@ -93,7 +111,7 @@ var CText = regexp.MustCompile(`^[\x09\x20\x21-\x27\x2A-\x5B\x5D-\x7E\x80-\xFF]$
 // that process the value of a quoted-string MUST handle a quoted-pair
 // as if it were replaced by the octet following the backslash.
 //
-// 	quoted-pair    = "\" ( HTAB / SP / VCHAR / obs-text )
+//	quoted-pair    = "\" ( HTAB / SP / VCHAR / obs-text )
 //
 // A sender SHOULD NOT generate a quoted-pair in a quoted-string except
 // where necessary to quote DQUOTE and backslash octets occurring within
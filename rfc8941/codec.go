@@ -0,0 +1,398 @@
+package rfc8941
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+/*
+Marshal and Unmarshal map Go structs and slices to Structured Field Values
+using `sfv` struct tags, so that callers working with real HTTP fields like
+Cache-Control, Accept-CH, Priority, or Signature-Input don't have to
+hand-build Item/InnerList/Parameters values and type-switch on the legacy
+any-shaped codec in textparse.go/textserialize.go.
+
+# Tag syntax
+
+	`sfv:"key,opt,opt,..."`
+
+key is the Dictionary key (ignored for List elements). If key is empty and
+no tag is present, the lowercased field name is used. Recognized options:
+
+  - token: a string field serializes as a Token instead of a String.
+  - value: marks the bare-item field of an "entry struct" (see below).
+  - params: marks the Parameters field of an entry struct.
+  - inner: documents that a slice field is an Inner List; slice fields are
+    always treated as Inner Lists regardless of this option (byte slices
+    are the one exception: they are always a Byte Sequence bare item).
+  - omitempty: Marshal skips a zero-valued field instead of including it;
+    Unmarshal leaves the field at its zero value instead of failing when
+    the key is absent.
+
+# Entry structs
+
+A Dictionary value or List member that needs Parameters is represented by
+a nested struct with a "value"-tagged bare-item field and a "params"-tagged
+rfc8941.Parameters field:
+
+	type cacheControlEntry struct {
+		Value  int64      `sfv:",value"`
+		Params Parameters `sfv:",params"`
+	}
+
+# Supported bare item Go types
+
+int and all sized int types (Integer), float32/float64 (Decimal), string
+(String, or Token with the token option), bool (Boolean), []byte (ByteSeq),
+and the typed rfc8941.Token, rfc8941.ByteSeq, rfc8941.Date, and
+rfc8941.DisplayString types directly (Date and DisplayString require
+RFC9651 to be true when serializing or parsing).
+*/
+
+type tagOpts struct {
+	token     bool
+	value     bool
+	params    bool
+	inner     bool
+	omitempty bool
+}
+
+func parseTag(tag string) (string, tagOpts) {
+	parts := strings.Split(tag, ",")
+	key := parts[0]
+	var opts tagOpts
+	for _, opt := range parts[1:] {
+		switch opt {
+		case "token":
+			opts.token = true
+		case "value":
+			opts.value = true
+		case "params":
+			opts.params = true
+		case "inner":
+			opts.inner = true
+		case "omitempty":
+			opts.omitempty = true
+		}
+	}
+	return key, opts
+}
+
+func fieldTag(f reflect.StructField) (string, tagOpts) {
+	tag, ok := f.Tag.Lookup("sfv")
+	if !ok {
+		return strings.ToLower(f.Name), tagOpts{}
+	}
+	key, opts := parseTag(tag)
+	if key == "" && !opts.value && !opts.params {
+		key = strings.ToLower(f.Name)
+	}
+	return key, opts
+}
+
+// entryStructFields reports the field indexes of an entry struct's
+// "value"- and "params"-tagged fields, per the Entry structs convention
+// documented above.
+func entryStructFields(t reflect.Type) (valueIdx, paramsIdx int, ok bool) {
+	valueIdx, paramsIdx = -1, -1
+	for i := 0; i < t.NumField(); i++ {
+		_, opts := fieldTag(t.Field(i))
+		if opts.value {
+			valueIdx = i
+		}
+		if opts.params {
+			paramsIdx = i
+		}
+	}
+	return valueIdx, paramsIdx, valueIdx != -1
+}
+
+// Marshal returns the Structured Field Value text serialization of v. v
+// must be a struct or a pointer to one (serialized as a Dictionary, per
+// Section 3.2) or a slice (serialized as a List, per Section 3.1).
+func Marshal(v any) ([]byte, error) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return nil, fmt.Errorf("rfc8941: Marshal called with a nil pointer")
+		}
+		rv = rv.Elem()
+	}
+	switch rv.Kind() {
+	case reflect.Struct:
+		dict, err := marshalDictionary(rv)
+		if err != nil {
+			return nil, err
+		}
+		return TextSerialize(dict)
+	case reflect.Slice:
+		list, err := marshalList(rv)
+		if err != nil {
+			return nil, err
+		}
+		return TextSerialize(list)
+	default:
+		return nil, fmt.Errorf("rfc8941: unsupported Marshal type %s", rv.Type())
+	}
+}
+
+func marshalDictionary(rv reflect.Value) (Dictionary, error) {
+	var dict Dictionary
+	t := rv.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		key, opts := fieldTag(f)
+		fv := rv.Field(i)
+		if opts.omitempty && fv.IsZero() {
+			continue
+		}
+		member, err := marshalMember(fv, opts)
+		if err != nil {
+			return Dictionary{}, fmt.Errorf("rfc8941: field %s: %w", f.Name, err)
+		}
+		switch m := member.(type) {
+		case Item:
+			dict.Set(key, m.Value, m.Parameters)
+		case InnerList:
+			dict.SetInnerList(key, m)
+		}
+	}
+	return dict, nil
+}
+
+func marshalList(rv reflect.Value) (List, error) {
+	var list List
+	for i := 0; i < rv.Len(); i++ {
+		member, err := marshalMember(rv.Index(i), tagOpts{})
+		if err != nil {
+			return nil, fmt.Errorf("rfc8941: element %d: %w", i, err)
+		}
+		switch m := member.(type) {
+		case Item:
+			list.Append(m.Value, m.Parameters)
+		case InnerList:
+			list.AppendInnerList(m)
+		}
+	}
+	return list, nil
+}
+
+// marshalMember converts a single Go value (a Dictionary value or List
+// element) into an Item or InnerList.
+func marshalMember(rv reflect.Value, opts tagOpts) (ListMember, error) {
+	if rv.Kind() == reflect.Struct {
+		if valueIdx, paramsIdx, ok := entryStructFields(rv.Type()); ok {
+			_, valueOpts := fieldTag(rv.Type().Field(valueIdx))
+			bareItem, err := bareItemFromGoValue(rv.Field(valueIdx), valueOpts.token)
+			if err != nil {
+				return nil, err
+			}
+			var params Parameters
+			if paramsIdx != -1 {
+				if p, ok := rv.Field(paramsIdx).Interface().(Parameters); ok {
+					params = p
+				}
+			}
+			return Item{Value: bareItem, Parameters: params}, nil
+		}
+	}
+
+	if rv.Kind() == reflect.Slice && rv.Type().Elem().Kind() != reflect.Uint8 {
+		items := make([]Item, rv.Len())
+		for i := 0; i < rv.Len(); i++ {
+			member, err := marshalMember(rv.Index(i), tagOpts{})
+			if err != nil {
+				return nil, fmt.Errorf("inner list element %d: %w", i, err)
+			}
+			item, ok := member.(Item)
+			if !ok {
+				return nil, fmt.Errorf("inner list element %d must be a bare item, not a nested Inner List", i)
+			}
+			items[i] = item
+		}
+		return InnerList{Items: items}, nil
+	}
+
+	bareItem, err := bareItemFromGoValue(rv, opts.token)
+	if err != nil {
+		return nil, err
+	}
+	return Item{Value: bareItem}, nil
+}
+
+func bareItemFromGoValue(rv reflect.Value, token bool) (BareItem, error) {
+	switch v := rv.Interface().(type) {
+	case BareItem:
+		return v, nil
+	}
+	switch rv.Kind() {
+	case reflect.String:
+		if token {
+			return NewToken(rv.String())
+		}
+		return NewString(rv.String())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return NewInteger(rv.Int())
+	case reflect.Float32, reflect.Float64:
+		return NewDecimal(rv.Float())
+	case reflect.Bool:
+		return NewBoolean(rv.Bool()), nil
+	case reflect.Slice:
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			return NewByteSeq(rv.Bytes()), nil
+		}
+	}
+	return nil, fmt.Errorf("rfc8941: unsupported bare item type %s", rv.Type())
+}
+
+// Unmarshal parses a Structured Field Value in data into v. v must be a
+// pointer to a struct (parsed as a Dictionary) or a pointer to a slice
+// (parsed as a List).
+func Unmarshal(data []byte, v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return fmt.Errorf("rfc8941: Unmarshal target must be a non-nil pointer")
+	}
+	elem := rv.Elem()
+	switch elem.Kind() {
+	case reflect.Struct:
+		parsed, err := TextParse(data, "dictionary")
+		if err != nil {
+			return err
+		}
+		dict, err := DictionaryFromLegacy(parsed)
+		if err != nil {
+			return err
+		}
+		return unmarshalDictionary(dict, elem)
+	case reflect.Slice:
+		parsed, err := TextParse(data, "list")
+		if err != nil {
+			return err
+		}
+		list, err := ListFromLegacy(parsed)
+		if err != nil {
+			return err
+		}
+		return unmarshalList(list, elem)
+	default:
+		return fmt.Errorf("rfc8941: unsupported Unmarshal target type %s", elem.Type())
+	}
+}
+
+func unmarshalDictionary(dict Dictionary, rv reflect.Value) error {
+	t := rv.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		key, opts := fieldTag(f)
+		member, ok := dict.Get(key)
+		if !ok {
+			if opts.omitempty {
+				continue
+			}
+			return fmt.Errorf("rfc8941: missing required dictionary key %q", key)
+		}
+		if err := unmarshalMember(rv.Field(i), member, opts); err != nil {
+			return fmt.Errorf("rfc8941: field %s: %w", f.Name, err)
+		}
+	}
+	return nil
+}
+
+func unmarshalList(list List, rv reflect.Value) error {
+	out := reflect.MakeSlice(rv.Type(), len(list), len(list))
+	for i, member := range list {
+		if err := unmarshalMember(out.Index(i), member, tagOpts{}); err != nil {
+			return fmt.Errorf("rfc8941: element %d: %w", i, err)
+		}
+	}
+	rv.Set(out)
+	return nil
+}
+
+// unmarshalMember sets fv (the Go value for a Dictionary value or List
+// element) from member.
+func unmarshalMember(fv reflect.Value, member ListMember, opts tagOpts) error {
+	switch m := member.(type) {
+	case InnerList:
+		if fv.Kind() != reflect.Slice {
+			return fmt.Errorf("Inner List requires a slice field, got %s", fv.Type())
+		}
+		out := reflect.MakeSlice(fv.Type(), len(m.Items), len(m.Items))
+		for i, it := range m.Items {
+			if err := unmarshalMember(out.Index(i), it, tagOpts{}); err != nil {
+				return fmt.Errorf("inner list element %d: %w", i, err)
+			}
+		}
+		fv.Set(out)
+		return nil
+	case Item:
+		if fv.Kind() == reflect.Struct {
+			if valueIdx, paramsIdx, ok := entryStructFields(fv.Type()); ok {
+				if err := setBareItemField(fv.Field(valueIdx), m.Value); err != nil {
+					return err
+				}
+				if paramsIdx != -1 {
+					fv.Field(paramsIdx).Set(reflect.ValueOf(m.Parameters))
+				}
+				return nil
+			}
+		}
+		return setBareItemField(fv, m.Value)
+	default:
+		return fmt.Errorf("rfc8941: unsupported member type %T", member)
+	}
+}
+
+func setBareItemField(rv reflect.Value, bareItem BareItem) error {
+	biVal := reflect.ValueOf(bareItem)
+	if biVal.Type().AssignableTo(rv.Type()) {
+		rv.Set(biVal)
+		return nil
+	}
+	switch v := bareItem.(type) {
+	case Integer:
+		if rv.CanInt() {
+			rv.SetInt(int64(v))
+			return nil
+		}
+	case Decimal:
+		if rv.CanFloat() {
+			rv.SetFloat(float64(v))
+			return nil
+		}
+	case String:
+		if rv.Kind() == reflect.String {
+			rv.SetString(string(v))
+			return nil
+		}
+	case Token:
+		if rv.Kind() == reflect.String {
+			rv.SetString(string(v))
+			return nil
+		}
+	case Boolean:
+		if rv.Kind() == reflect.Bool {
+			rv.SetBool(bool(v))
+			return nil
+		}
+	case ByteSeq:
+		if rv.Kind() == reflect.Slice && rv.Type().Elem().Kind() == reflect.Uint8 {
+			rv.SetBytes([]byte(v))
+			return nil
+		}
+	case DisplayString:
+		if rv.Kind() == reflect.String {
+			rv.SetString(string(v))
+			return nil
+		}
+	}
+	return fmt.Errorf("rfc8941: cannot assign %#+v into %s", bareItem, rv.Type())
+}
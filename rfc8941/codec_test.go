@@ -0,0 +1,60 @@
+package rfc8941
+
+import "testing"
+
+type cacheControlHeader struct {
+	NoCache bool  `sfv:"no-cache,omitempty"`
+	MaxAge  int64 `sfv:"max-age"`
+}
+
+func TestMarshalUnmarshalDictionary(t *testing.T) {
+	in := cacheControlHeader{MaxAge: 600, NoCache: true}
+	data, err := Marshal(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Logf("data=%q", data)
+
+	var out cacheControlHeader
+	if err := Unmarshal(data, &out); err != nil {
+		t.Fatal(err)
+	}
+	if out != in {
+		t.Errorf("got %#+v, want %#+v", out, in)
+	}
+}
+
+type acceptCHEntry struct {
+	Value  Token      `sfv:",value"`
+	Params Parameters `sfv:",params"`
+}
+
+func TestMarshalUnmarshalListWithParams(t *testing.T) {
+	in := []acceptCHEntry{
+		{Value: "sec-ch-ua"},
+		{Value: "sec-ch-ua-platform"},
+	}
+	data, err := Marshal(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Logf("data=%q", data)
+	if string(data) != "sec-ch-ua, sec-ch-ua-platform" {
+		t.Errorf("got %q", data)
+	}
+
+	var out []acceptCHEntry
+	if err := Unmarshal(data, &out); err != nil {
+		t.Fatal(err)
+	}
+	if len(out) != len(in) || out[0].Value != in[0].Value || out[1].Value != in[1].Value {
+		t.Errorf("got %#+v, want %#+v", out, in)
+	}
+}
+
+func TestUnmarshalMissingRequiredKey(t *testing.T) {
+	var out cacheControlHeader
+	if err := Unmarshal([]byte(`no-cache, foo=1`), &out); err == nil {
+		t.Error("expected an error for a missing required key, got nil")
+	}
+}
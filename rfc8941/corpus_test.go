@@ -0,0 +1,140 @@
+package rfc8941_test
+
+import (
+	"flag"
+	"testing"
+
+	"github.com/jcbhmr/go-fetch/internal/sfvtest"
+	"github.com/jcbhmr/go-fetch/rfc8941"
+)
+
+// update regenerates the `canonical` field of the vendored corpus fixtures
+// from this package's own TextSerialize output, the way golden files are
+// normally refreshed: `go test ./rfc8941/... -run TestCorpus -update`.
+var update = flag.Bool("update", false, "regenerate canonical golden outputs in testdata/httpwg")
+
+const corpusDir = "testdata/httpwg"
+
+// toTyped converts the legacy any-shaped value TextParse returns into this
+// module's typed List/Dictionary/Item representation, so sfvtest.Comparable
+// and rfc8941.TextSerialize can both be exercised against the same value.
+func toTyped(headerType string, parsed rfc8941.StructuredFieldValue) (any, error) {
+	switch headerType {
+	case "list":
+		return rfc8941.ListFromLegacy(parsed)
+	case "dictionary":
+		return rfc8941.DictionaryFromLegacy(parsed)
+	case "item":
+		return rfc8941.ItemFromLegacy(parsed)
+	default:
+		panic("unreachable")
+	}
+}
+
+// runCorpus parses and re-serializes every case in testdata/httpwg/file,
+// checking TextParse against the case's `expected` value and
+// TextSerialize against its `canonical` form (or, if canonical is absent,
+// the joined raw input — the upstream convention that raw is already
+// canonical unless a case overrides it).
+func runCorpus(t *testing.T, headerType, file string) {
+	cases, err := sfvtest.Load(corpusDir, file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	changed := false
+	for i := range cases {
+		tt := cases[i]
+		t.Run(tt.Name, func(t *testing.T) {
+			raw := sfvtest.JoinRaw(tt.Raw)
+			parsed, err := rfc8941.TextParse(raw, headerType)
+			if tt.MustFail {
+				if err == nil {
+					t.Fatalf("TextParse(%q) = %v, want an error", raw, parsed)
+				}
+				return
+			}
+			if err != nil {
+				if tt.CanFail {
+					t.Skipf("TextParse(%q) failed (allowed by can_fail): %v", raw, err)
+				}
+				t.Fatalf("TextParse(%q) failed: %v", raw, err)
+			}
+
+			typed, err := toTyped(headerType, parsed)
+			if err != nil {
+				t.Fatalf("converting %#+v to the typed API: %v", parsed, err)
+			}
+
+			if len(tt.Expected) > 0 {
+				ok, gotJSON, wantJSON, err := sfvtest.EqualExpected(tt, typed)
+				if err != nil {
+					t.Fatalf("comparing expected value: %v", err)
+				}
+				if !ok {
+					t.Errorf("TextParse(%q) = %s, want %s", raw, gotJSON, wantJSON)
+				}
+			}
+
+			serialized, err := rfc8941.TextSerialize(typed)
+			if err != nil {
+				if tt.CanFail {
+					t.Skipf("TextSerialize(%#+v) failed (allowed by can_fail): %v", typed, err)
+				}
+				t.Fatalf("TextSerialize(%#+v) failed: %v", typed, err)
+			}
+
+			// Only cases with an explicit `canonical` field are checked
+			// against TextSerialize's output: unlike `raw`, canonical
+			// reserialization isn't always equal to the original input
+			// (e.g. leading whitespace or a leading zero aren't
+			// preserved), so there is no safe default to fall back to.
+			if len(tt.Canonical) == 0 {
+				if *update {
+					cases[i].Canonical = []string{string(serialized)}
+					changed = true
+				}
+				return
+			}
+			want := sfvtest.JoinRaw(tt.Canonical)
+			if *update {
+				if string(serialized) != string(want) {
+					cases[i].Canonical = []string{string(serialized)}
+					changed = true
+				}
+				return
+			}
+			if string(serialized) != string(want) {
+				t.Errorf("TextSerialize(TextParse(%q)) = %q, want %q", raw, serialized, want)
+			}
+		})
+	}
+	if *update && changed {
+		if err := sfvtest.Save(corpusDir, file, cases); err != nil {
+			t.Fatalf("writing updated corpus: %v", err)
+		}
+	}
+}
+
+// TestCorpus round-trips the vendored httpwg/structured-field-tests
+// fixtures through TextParse and TextSerialize, checking parse, typed-API
+// conversion, and serialization round-trip parity. The `number`, `string`,
+// `boolean`, and `token` bare item subdirectories cover plain RFC 8941;
+// `date` and `displaystring` cover the RFC 9651 extension (Section
+// chunk1-1) and only run with rfc8941.RFC9651 set, so future additions to
+// the 9651 corpus can be enabled by flipping that one flag.
+func TestCorpus(t *testing.T) {
+	t.Run("number", func(t *testing.T) { runCorpus(t, "item", "number.json") })
+	t.Run("string", func(t *testing.T) { runCorpus(t, "item", "string.json") })
+	t.Run("boolean", func(t *testing.T) { runCorpus(t, "item", "boolean.json") })
+	t.Run("token", func(t *testing.T) { runCorpus(t, "item", "token.json") })
+	t.Run("list", func(t *testing.T) { runCorpus(t, "list", "list.json") })
+	t.Run("dictionary", func(t *testing.T) { runCorpus(t, "dictionary", "dictionary.json") })
+
+	t.Run("rfc9651", func(t *testing.T) {
+		previous := rfc8941.RFC9651
+		rfc8941.RFC9651 = true
+		defer func() { rfc8941.RFC9651 = previous }()
+		t.Run("date", func(t *testing.T) { runCorpus(t, "item", "date.json") })
+		t.Run("displaystring", func(t *testing.T) { runCorpus(t, "item", "displaystring.json") })
+	})
+}
@@ -0,0 +1,89 @@
+package rfc8941
+
+import "fmt"
+
+/*
+Every Parse* function previously returned a bare fmt.Errorf("parsing
+failed: %s", ...), which loses the original offset once the *string has
+already been resliced up to the failure point, and gives callers nothing
+to switch on besides the error string. ParseError carries that offset
+(threaded through from Parser.Pos(), so it is always measured against the
+original input) plus a Kind classifying the failure, so a caller can tell
+e.g. a malformed field (ErrSyntax) from a value that parsed fine but
+violates a limit (ErrIntegerRange, ErrDecimalPrecision) without
+string-matching Error().
+*/
+
+// ErrKind classifies why a Parse* function in this package failed.
+type ErrKind int
+
+const (
+	// ErrSyntax is a plain grammar violation: an expected byte, delimiter,
+	// or bare item type was not where it should be.
+	ErrSyntax ErrKind = iota
+	// ErrEOF is a syntax violation specifically caused by running out of
+	// input before a structure (a string, byte sequence, or inner list)
+	// was closed.
+	ErrEOF
+	// ErrIntegerRange is an Integer, or a Decimal's integer component,
+	// with more significant digits than Section 3.3.1/3.3.2 allow.
+	ErrIntegerRange
+	// ErrDecimalPrecision is a Decimal with more than three fractional
+	// digits (Section 3.3.2).
+	ErrDecimalPrecision
+	// ErrBadEscape is a malformed escape sequence in a String or Display
+	// String.
+	ErrBadEscape
+	// ErrNonASCII is input containing a byte outside the ASCII range
+	// where the grammar requires an ASCII string (Section 4.2, step 1).
+	ErrNonASCII
+)
+
+func (k ErrKind) String() string {
+	switch k {
+	case ErrSyntax:
+		return "syntax"
+	case ErrEOF:
+		return "eof"
+	case ErrIntegerRange:
+		return "integer range"
+	case ErrDecimalPrecision:
+		return "decimal precision"
+	case ErrBadEscape:
+		return "bad escape"
+	case ErrNonASCII:
+		return "non-ASCII"
+	default:
+		return "unknown"
+	}
+}
+
+// ParseError is returned by this package's Parse* functions on failure.
+type ParseError struct {
+	// Offset is the byte offset into the original input at which parsing
+	// failed.
+	Offset int
+	// Kind classifies the failure.
+	Kind ErrKind
+	// Msg describes the failure in more detail.
+	Msg string
+
+	wrapped error
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("parsing failed at byte %d: %s", e.Offset, e.Msg)
+}
+
+// Unwrap returns the underlying error wrapped by newParseErrorWrap, if any.
+func (e *ParseError) Unwrap() error {
+	return e.wrapped
+}
+
+func newParseError(offset int, kind ErrKind, format string, args ...any) error {
+	return &ParseError{Offset: offset, Kind: kind, Msg: fmt.Sprintf(format, args...)}
+}
+
+func newParseErrorWrap(offset int, kind ErrKind, wrapped error) error {
+	return &ParseError{Offset: offset, Kind: kind, Msg: wrapped.Error(), wrapped: wrapped}
+}
@@ -0,0 +1,40 @@
+package rfc8941
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseErrorOffsetAndKind(t *testing.T) {
+	tests := []struct {
+		name       string
+		inputBytes []byte
+		fieldType  string
+		wantKind   ErrKind
+		wantOffset int
+	}{
+		{"trailing comma", []byte(`text/html,`), "list", ErrSyntax, 10},
+		{"integer too long", []byte(`1000000000000000`), "item", ErrIntegerRange, 16},
+		{"too many fractional digits", []byte(`1.2345`), "item", ErrDecimalPrecision, 6},
+		{"unterminated string", []byte(`"foo`), "item", ErrEOF, 4},
+		{"non-ASCII", []byte("text/html\xff"), "item", ErrNonASCII, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := TextParse(tt.inputBytes, tt.fieldType)
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			var parseErr *ParseError
+			if !errors.As(err, &parseErr) {
+				t.Fatalf("err = %v (%T), want a *ParseError", err, err)
+			}
+			if parseErr.Kind != tt.wantKind {
+				t.Errorf("Kind = %v, want %v", parseErr.Kind, tt.wantKind)
+			}
+			if parseErr.Offset != tt.wantOffset {
+				t.Errorf("Offset = %d, want %d", parseErr.Offset, tt.wantOffset)
+			}
+		})
+	}
+}
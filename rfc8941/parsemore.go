@@ -0,0 +1,70 @@
+package rfc8941
+
+import "fmt"
+
+/*
+RFC 8941 §3.1/§3.2 require that a List or Dictionary split across
+multiple field lines be combined by concatenating the lines with ", "
+before parsing -- a caller that instead has each line already parsed
+separately (e.g. because it read them from net/textproto one at a time)
+would otherwise have to re-serialize and re-parse from scratch. ParseMore*
+lets such a caller fold one more line's members into an already-parsed
+List or Dictionary directly, as if it had been parsed as part of the
+combined value.
+
+Per §3.1, inner items (bare items and their parameters) may not be split
+across lines; nextLine is always parsed as a complete List or Dictionary
+in its own right; it is never concatenated byte-for-byte with a partial
+member from a previous line.
+*/
+
+// ParseMoreList parses nextLine as a List and appends its members to
+// *existing, as if nextLine had been combined with the input that
+// produced *existing using ", " and parsed as one field line. On error,
+// *existing is left unchanged.
+func ParseMoreList(existing *legacyList, nextLine []byte) error {
+	value, err := TextParse(nextLine, "list")
+	if err != nil {
+		return fmt.Errorf("rfc8941: ParseMoreList: %w", err)
+	}
+	more, ok := value.(legacyList)
+	if !ok {
+		return fmt.Errorf("rfc8941: ParseMoreList: %#v is not a parsed list", value)
+	}
+	*existing = append(*existing, more...)
+	return nil
+}
+
+// ParseMoreDictionary parses nextLine as a Dictionary and merges its
+// members into *existing, as if nextLine had been combined with the
+// input that produced *existing using ", " and parsed as one field line:
+// a key already present in *existing has its value overwritten in place
+// (keeping its original position), and a new key is appended. On error,
+// *existing is left unchanged.
+func ParseMoreDictionary(existing *legacyDictionary, nextLine []byte) error {
+	value, err := TextParse(nextLine, "dictionary")
+	if err != nil {
+		return fmt.Errorf("rfc8941: ParseMoreDictionary: %w", err)
+	}
+	more, ok := value.(legacyDictionary)
+	if !ok {
+		return fmt.Errorf("rfc8941: ParseMoreDictionary: %#v is not a parsed dictionary", value)
+	}
+
+	merged := *existing
+	for _, kv := range more {
+		replaced := false
+		for i, existingKV := range merged {
+			if existingKV.V1 == kv.V1 {
+				merged[i] = kv
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			merged = append(merged, kv)
+		}
+	}
+	*existing = merged
+	return nil
+}
@@ -0,0 +1,59 @@
+package rfc8941
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseMoreList(t *testing.T) {
+	existing, err := ParseList(ptr("a, b"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ParseMoreList(&existing, []byte("c, d")); err != nil {
+		t.Fatal(err)
+	}
+
+	combined, err := ParseList(ptr("a, b, c, d"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(existing, combined) {
+		t.Errorf("ParseMoreList result = %#v, want %#v", existing, combined)
+	}
+}
+
+func TestParseMoreListLeavesExistingOnError(t *testing.T) {
+	existing, err := ParseList(ptr("a, b"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	before := append(legacyList{}, existing...)
+
+	if err := ParseMoreList(&existing, []byte("not a valid token !!!")); err == nil {
+		t.Fatal("expected an error")
+	}
+	if !reflect.DeepEqual(existing, before) {
+		t.Errorf("ParseMoreList mutated existing on error: got %#v, want %#v", existing, before)
+	}
+}
+
+func TestParseMoreDictionary(t *testing.T) {
+	existing, err := ParseDictionary(ptr("a=1, b=2"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// "b" is overwritten in place, "c" is appended, matching what parsing
+	// "a=1, b=2, b=3, c=4" as one combined field line would produce.
+	if err := ParseMoreDictionary(&existing, []byte("b=3, c=4")); err != nil {
+		t.Fatal(err)
+	}
+
+	combined, err := ParseDictionary(ptr("a=1, b=3, c=4"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(existing, combined) {
+		t.Errorf("ParseMoreDictionary result = %#v, want %#v", existing, combined)
+	}
+}
@@ -0,0 +1,91 @@
+package rfc8941
+
+import (
+	"bufio"
+	"io"
+)
+
+/*
+The Parse* functions elsewhere in this package take a *string and slice
+it in place, which forces the entire field value to be read into memory
+and then repeatedly resliced. Parser is a streaming alternative: a cursor
+over an io.Reader exposing the same Peek/consume shape the *string
+functions use, but backed by a *bufio.Reader instead of a string header.
+The *string functions are kept as thin wrappers around the *FromParser
+functions below, for compatibility with existing callers.
+*/
+
+// Parser is a byte-at-a-time cursor over an io.Reader.
+type Parser struct {
+	br  *bufio.Reader
+	pos int
+}
+
+// NewParser returns a Parser reading from r.
+func NewParser(r io.Reader) *Parser {
+	return &Parser{br: bufio.NewReader(r)}
+}
+
+// Pos reports how many bytes have been consumed from the Parser so far.
+func (p *Parser) Pos() int {
+	return p.pos
+}
+
+// AtEOF reports whether the Parser has been exhausted.
+func (p *Parser) AtEOF() bool {
+	_, err := p.br.Peek(1)
+	return err != nil
+}
+
+// PeekByte returns the next byte without consuming it, reporting false
+// if the Parser is exhausted.
+func (p *Parser) PeekByte() (byte, bool) {
+	b, err := p.br.Peek(1)
+	if err != nil {
+		return 0, false
+	}
+	return b[0], true
+}
+
+// Peek2 returns the next two bytes without consuming them, reporting
+// false if fewer than two bytes remain.
+func (p *Parser) Peek2() ([2]byte, bool) {
+	b, err := p.br.Peek(2)
+	if err != nil {
+		return [2]byte{}, false
+	}
+	return [2]byte{b[0], b[1]}, true
+}
+
+// ReadByte consumes and returns the next byte, reporting false if the
+// Parser is exhausted. It implements io.ByteReader.
+func (p *Parser) ReadByte() (byte, error) {
+	b, err := p.br.ReadByte()
+	if err != nil {
+		return 0, err
+	}
+	p.pos++
+	return b, nil
+}
+
+// Expect consumes the next byte if it equals b, reporting whether it
+// did.
+func (p *Parser) Expect(b byte) bool {
+	c, ok := p.PeekByte()
+	if !ok || c != b {
+		return false
+	}
+	p.ReadByte()
+	return true
+}
+
+// SkipWhile consumes leading bytes for which inSet returns true.
+func (p *Parser) SkipWhile(inSet func(byte) bool) {
+	for {
+		b, ok := p.PeekByte()
+		if !ok || !inSet(b) {
+			return
+		}
+		p.ReadByte()
+	}
+}
@@ -0,0 +1,175 @@
+package rfc8941
+
+import (
+	"fmt"
+
+	"github.com/barweiss/go-tuple"
+)
+
+/*
+ItemSerializer, ListSerializer, DictSerializer, and InnerListSerializer are
+"by-reference" builders, in the style of the Rust `sfv` crate's
+ref_serializer module: they accept plain Go bare item values (int64,
+float64, string, rfc8941.Token, []byte, bool, or — with RFC9651 set —
+rfc8941.Date/rfc8941.DisplayString) plus Parameters and emit the ASCII
+field value directly, without the caller constructing List/Dictionary/Item
+values first. They are the natural counterpart to ParseItem/ParseList/
+ParseDictionary: building a field like `Priority: u=1, i` or a
+Signature-Input dictionary is a handful of chained calls, not a tuple tree.
+*/
+
+// ItemSerializer builds a single Item's ASCII form.
+type ItemSerializer struct {
+	value    any
+	hasValue bool
+	params   Parameters
+}
+
+// NewItemSerializer returns an empty ItemSerializer.
+func NewItemSerializer() *ItemSerializer {
+	return &ItemSerializer{}
+}
+
+// BareItem sets the Item's bare item value.
+func (s *ItemSerializer) BareItem(value any) *ItemSerializer {
+	s.value = value
+	s.hasValue = true
+	return s
+}
+
+// Parameter appends one Parameter.
+func (s *ItemSerializer) Parameter(key string, value any) *ItemSerializer {
+	s.params = append(s.params, tuple.New2(key, value))
+	return s
+}
+
+// Finish returns the completed Item's ASCII form.
+func (s *ItemSerializer) Finish() (string, error) {
+	if !s.hasValue {
+		return "", fmt.Errorf("rfc8941: ItemSerializer: BareItem was never called")
+	}
+	return SerItem(s.value, s.params)
+}
+
+// String returns the completed Item's ASCII form, or a placeholder
+// containing the error if serialization fails. It implements
+// fmt.Stringer; callers that need the error should use Finish instead.
+func (s *ItemSerializer) String() string {
+	out, err := s.Finish()
+	if err != nil {
+		return fmt.Sprintf("%%!s(rfc8941.ItemSerializer: %s)", err)
+	}
+	return out
+}
+
+// innerListAppender is implemented by ListSerializer and DictSerializer so
+// that an InnerListSerializer can hand its finished Inner List back to
+// whichever builder opened it, regardless of which one that was.
+type innerListAppender interface {
+	appendInnerList(key string, inner legacyInnerList, params Parameters)
+}
+
+// ListSerializer builds a List's ASCII form.
+type ListSerializer struct {
+	members legacyList
+}
+
+// NewListSerializer returns an empty ListSerializer.
+func NewListSerializer() *ListSerializer {
+	return &ListSerializer{}
+}
+
+// BareItem appends a List member that is a bare item.
+func (s *ListSerializer) BareItem(value any, params Parameters) *ListSerializer {
+	s.members = append(s.members, tuple.New2[ItemOrInnerList, Parameters](value, params))
+	return s
+}
+
+// InnerList returns a builder for a List member that is an Inner List.
+// Call End on the returned InnerListSerializer to append it to this List.
+func (s *ListSerializer) InnerList() *InnerListSerializer {
+	return &InnerListSerializer{parent: s}
+}
+
+func (s *ListSerializer) appendInnerList(_ string, inner legacyInnerList, params Parameters) {
+	s.members = append(s.members, tuple.New2[ItemOrInnerList, Parameters](inner, params))
+}
+
+// Finish returns the completed List's ASCII form.
+func (s *ListSerializer) Finish() (string, error) {
+	return SerList(s.members)
+}
+
+// String returns the completed List's ASCII form, or a placeholder
+// containing the error if serialization fails. It implements
+// fmt.Stringer; callers that need the error should use Finish instead.
+func (s *ListSerializer) String() string {
+	out, err := s.Finish()
+	if err != nil {
+		return fmt.Sprintf("%%!s(rfc8941.ListSerializer: %s)", err)
+	}
+	return out
+}
+
+// DictSerializer builds a Dictionary's ASCII form.
+type DictSerializer struct {
+	members legacyDictionary
+}
+
+// NewDictSerializer returns an empty DictSerializer.
+func NewDictSerializer() *DictSerializer {
+	return &DictSerializer{}
+}
+
+// BareItem sets key's value to a bare item.
+func (s *DictSerializer) BareItem(key string, value any, params Parameters) *DictSerializer {
+	s.members = append(s.members, tuple.New2(key, tuple.New2[ItemOrInnerList, Parameters](value, params)))
+	return s
+}
+
+// InnerList returns a builder for key's value as an Inner List. Call End
+// on the returned InnerListSerializer to append it to this Dictionary.
+func (s *DictSerializer) InnerList(key string) *InnerListSerializer {
+	return &InnerListSerializer{parent: s, key: key}
+}
+
+func (s *DictSerializer) appendInnerList(key string, inner legacyInnerList, params Parameters) {
+	s.members = append(s.members, tuple.New2(key, tuple.New2[ItemOrInnerList, Parameters](inner, params)))
+}
+
+// Finish returns the completed Dictionary's ASCII form.
+func (s *DictSerializer) Finish() (string, error) {
+	return SerDictionary(s.members)
+}
+
+// String returns the completed Dictionary's ASCII form, or a placeholder
+// containing the error if serialization fails. It implements
+// fmt.Stringer; callers that need the error should use Finish instead.
+func (s *DictSerializer) String() string {
+	out, err := s.Finish()
+	if err != nil {
+		return fmt.Sprintf("%%!s(rfc8941.DictSerializer: %s)", err)
+	}
+	return out
+}
+
+// InnerListSerializer builds one Inner List member, nested inside a
+// ListSerializer or DictSerializer. It is created by ListSerializer.
+// InnerList or DictSerializer.InnerList.
+type InnerListSerializer struct {
+	parent innerListAppender
+	key    string
+	items  legacyInnerList
+}
+
+// BareItem appends an Item to the Inner List.
+func (s *InnerListSerializer) BareItem(value any, params Parameters) *InnerListSerializer {
+	s.items = append(s.items, tuple.New2(value, params))
+	return s
+}
+
+// End closes the Inner List, with params as its own Parameters, and
+// appends it to the ListSerializer or DictSerializer that created it.
+func (s *InnerListSerializer) End(params Parameters) {
+	s.parent.appendInnerList(s.key, s.items, params)
+}
@@ -0,0 +1,50 @@
+package rfc8941
+
+import (
+	"testing"
+
+	"github.com/barweiss/go-tuple"
+)
+
+func TestItemSerializerPriority(t *testing.T) {
+	// Priority: u=1, i
+	got, err := NewItemSerializer().
+		BareItem(int64(1)).
+		Parameter("u", int64(1)).
+		Finish()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "1;u=1" {
+		t.Errorf("got %q", got)
+	}
+}
+
+func TestDictSerializerSignatureInput(t *testing.T) {
+	ds := NewDictSerializer()
+	ds.InnerList("sig1").
+		BareItem("@authority", nil).
+		BareItem(Token("content-digest"), nil).
+		End(Parameters{tuple.New2[string, any]("keyid", "test-key")})
+	got, err := ds.Finish()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `sig1=("@authority" content-digest);keyid="test-key"`
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestListSerializerMixed(t *testing.T) {
+	ls := NewListSerializer()
+	ls.BareItem(int64(1), nil)
+	ls.InnerList().BareItem(Token("x"), nil).BareItem(Token("y"), nil).End(nil)
+	got, err := ls.Finish()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "1, (x y)" {
+		t.Errorf("got %q", got)
+	}
+}
@@ -0,0 +1,253 @@
+package rfc8941
+
+import (
+	"fmt"
+	"unicode/utf8"
+)
+
+/*
+RFC 9651 obsoletes RFC 8941 and adds two new bare item types: Date and
+Display String. Since they parse and serialize differently from any of the
+original six bare item types, support for them is off by default so that
+existing RFC 8941 callers are unaffected; set RFC9651 to true to opt in.
+
+https://www.rfc-editor.org/rfc/rfc9651.html
+*/
+
+// RFC9651 controls whether ParseBareItem, SerBareItem, and SerItem
+// recognize the Date and Display String bare item types added by RFC 9651.
+// It defaults to false, so existing RFC 8941 callers see no change in
+// behavior unless they opt in.
+var RFC9651 = false
+
+// Date is the Date bare item type added by RFC 9651 Section 3.3.7: an
+// integer number of seconds since the Unix epoch.
+type Date int64
+
+func (Date) bareItem() {}
+
+// NewDate validates value against the Date range (the same
+// ±999,999,999,999,999 bound as Integer; see Section 3.3.1), so that a
+// constructed Date can never fail to serialize.
+func NewDate(value int64) (Date, error) {
+	if value < -999999999999999 || value > 999999999999999 {
+		return 0, fmt.Errorf("rfc8941: date %d out of range", value)
+	}
+	return Date(value), nil
+}
+
+// DisplayString is the Display String bare item type added by RFC 9651
+// Section 3.3.8: a Unicode string serialized as percent-encoded UTF-8.
+type DisplayString string
+
+func (DisplayString) bareItem() {}
+
+// NewDisplayString validates that value is well-formed UTF-8, so that a
+// constructed DisplayString can never fail to serialize.
+func NewDisplayString(value string) (DisplayString, error) {
+	if !utf8.ValidString(value) {
+		return "", fmt.Errorf("rfc8941: display string %q is not valid UTF-8", value)
+	}
+	return DisplayString(value), nil
+}
+
+/*
+# 4.1.10. Serializing a Date
+
+https://www.rfc-editor.org/rfc/rfc9651.html#section-4.1.10
+*/
+
+// Given a Date as input_date, return an ASCII string suitable for use in an
+// HTTP field value.
+//
+// https://www.rfc-editor.org/rfc/rfc9651.html#section-4.1.10
+func SerDate(inputDate Date) (string, error) {
+	// 1. If input_date is not an integer in the range of
+	// -999,999,999,999,999 to 999,999,999,999,999 inclusive, fail
+	// serialization.
+	serializedInteger, err := SerInteger(int64(inputDate))
+	if err != nil {
+		return "", fmt.Errorf("serialization failed: %#+v", inputDate)
+	}
+	// 2. Return the string "@", followed by the result of running
+	// Serializing an Integer (Section 4.1.4) with input_date.
+	return "@" + serializedInteger, nil
+}
+
+/*
+# 4.1.11. Serializing a Display String
+
+https://www.rfc-editor.org/rfc/rfc9651.html#section-4.1.11
+*/
+
+// Given a Display String as input_display_string, return an ASCII string
+// suitable for use in an HTTP field value.
+//
+// https://www.rfc-editor.org/rfc/rfc9651.html#section-4.1.11
+func SerDisplayString(inputDisplayString DisplayString) (string, error) {
+	value := string(inputDisplayString)
+	// 1. If input_display_string is not a sequence of Unicode characters,
+	// fail serialization.
+	if !utf8.ValidString(value) {
+		return "", fmt.Errorf("serialization failed: %#+v", inputDisplayString)
+	}
+
+	// 2. Let byte_array be the result of applying UTF-8 encoding to
+	// input_display_string.
+	byteArray := []byte(value)
+
+	// 3. Let output be the string "%"".
+	output := `%"`
+
+	// 4. For each byte in byte_array:
+	for _, b := range byteArray {
+		// 1. If byte is %x25 ("%"), %x22 (DQUOTE), or is outside the range
+		// %x20-7E, append "%" to output, then append the result of
+		// hex-encoding byte to output, using lowercase hexadecimal digits.
+		if b == '%' || b == '"' || b < 0x20 || b > 0x7e {
+			output += fmt.Sprintf("%%%02x", b)
+		} else {
+			// 2. Otherwise, append a string containing byte to output.
+			output += string(b)
+		}
+	}
+
+	// 5. Append "" to output.
+	output += `"`
+
+	// 6. Return output.
+	return output, nil
+}
+
+/*
+# 4.2.10. Parsing a Date
+
+https://www.rfc-editor.org/rfc/rfc9651.html#section-4.2.9
+*/
+
+// Given an ASCII string as input_string, return a Date. input_string is
+// modified to remove the parsed value.
+//
+// https://www.rfc-editor.org/rfc/rfc9651.html#section-4.2.9
+func ParseDate(inputString *string) (Date, error) {
+	// 1. If the first character of input_string is not "@", fail parsing.
+	if len(*inputString) == 0 || (*inputString)[0] != '@' {
+		return 0, fmt.Errorf("parsing failed: %s", *inputString)
+	}
+	// 2. Discard the first character of input_string.
+	*inputString = (*inputString)[1:]
+
+	// 3. Let output_integer be the result of running Parsing an Integer or
+	// Decimal (Section 4.2.4) with input_string.
+	outputNumber, err := ParseNumber(inputString)
+	if err != nil {
+		return 0, err
+	}
+	outputInteger, ok := outputNumber.(int64)
+	if !ok {
+		return 0, fmt.Errorf("parsing failed: %s", *inputString)
+	}
+
+	// 4. If output_integer is a Decimal, fail parsing.
+	// 5. Return output_integer.
+	return Date(outputInteger), nil
+}
+
+/*
+# 4.2.10. Parsing a Display String
+
+https://www.rfc-editor.org/rfc/rfc9651.html#section-4.2.10
+*/
+
+// Given an ASCII string as input_string, return a Display String.
+// input_string is modified to remove the parsed value.
+//
+// https://www.rfc-editor.org/rfc/rfc9651.html#section-4.2.10
+func ParseDisplayString(inputString *string) (DisplayString, error) {
+	// 1. If the first two characters of input_string are not "%" followed
+	// by DQUOTE, fail parsing.
+	if len(*inputString) < 2 || (*inputString)[0] != '%' || (*inputString)[1] != '"' {
+		return "", fmt.Errorf("parsing failed: %s", *inputString)
+	}
+	// 2. Discard the first two characters of input_string.
+	*inputString = (*inputString)[2:]
+
+	// 3. Let byte_array be an empty byte array.
+	var byteArray []byte
+
+	// 4. While input_string is not empty:
+	for len(*inputString) > 0 {
+		// 1. Let char be the result of removing the first character of
+		// input_string.
+		char := (*inputString)[0]
+		*inputString = (*inputString)[1:]
+
+		// 2. If char is a DQUOTE, return the result of decoding byte_array
+		// as a UTF-8 string, failing parsing if byte_array is not a valid
+		// UTF-8 string.
+		if char == '"' {
+			if !utf8.Valid(byteArray) {
+				return "", fmt.Errorf("parsing failed: %s", byteArray)
+			}
+			return DisplayString(byteArray), nil
+		}
+
+		// 3. If char is "%", then:
+		if char == '%' {
+			// 1. If the first two characters of input_string are not
+			// lowercase hexadecimal digits, fail parsing.
+			if len(*inputString) < 2 || !isLowerHexDigit((*inputString)[0]) || !isLowerHexDigit((*inputString)[1]) {
+				return "", fmt.Errorf("parsing failed: %s", *inputString)
+			}
+			// 2. Let octet be the result of removing the first two
+			// characters of input_string and decoding them as hexadecimal
+			// digits.
+			octet, err := hexDigitsToByte((*inputString)[0], (*inputString)[1])
+			if err != nil {
+				return "", err
+			}
+			*inputString = (*inputString)[2:]
+			// 3. Append octet to byte_array.
+			byteArray = append(byteArray, octet)
+			continue
+		}
+
+		// 4. Else: If char is outside %x20-7E, fail parsing.
+		if char < 0x20 || char > 0x7e {
+			return "", fmt.Errorf("parsing failed: %c", char)
+		}
+		// Append the string representation of char to byte_array.
+		byteArray = append(byteArray, char)
+	}
+
+	// 5. Reached the end of input_string without finding a closing DQUOTE;
+	// fail parsing.
+	return "", fmt.Errorf("parsing failed: %s", *inputString)
+}
+
+func isLowerHexDigit(b byte) bool {
+	return (b >= '0' && b <= '9') || (b >= 'a' && b <= 'f')
+}
+
+func hexDigitsToByte(hi, lo byte) (byte, error) {
+	hiVal, err := hexDigitValue(hi)
+	if err != nil {
+		return 0, err
+	}
+	loVal, err := hexDigitValue(lo)
+	if err != nil {
+		return 0, err
+	}
+	return hiVal<<4 | loVal, nil
+}
+
+func hexDigitValue(b byte) (byte, error) {
+	switch {
+	case b >= '0' && b <= '9':
+		return b - '0', nil
+	case b >= 'a' && b <= 'f':
+		return b - 'a' + 10, nil
+	default:
+		return 0, fmt.Errorf("parsing failed: %c is not a lowercase hexadecimal digit", b)
+	}
+}
@@ -0,0 +1,69 @@
+package rfc8941
+
+import "testing"
+
+// TestRFC9651GatesDateAndDisplayString confirms the Date/DisplayString
+// support added in chunk1-1 is what this request asked for: ParseBareItem
+// only dispatches "@"/"%" to Date/Display String parsing when RFC9651 is
+// set, so strict RFC 8941 callers still reject them by default.
+func TestRFC9651GatesDateAndDisplayString(t *testing.T) {
+	previous := RFC9651
+	defer func() { RFC9651 = previous }()
+
+	for _, input := range []string{`@1659578233`, `%"Hello"`} {
+		RFC9651 = false
+		s := input
+		if _, err := ParseBareItem(&s); err == nil {
+			t.Errorf("ParseBareItem(%q) with RFC9651=false: expected an error", input)
+		}
+
+		RFC9651 = true
+		s = input
+		if _, err := ParseBareItem(&s); err != nil {
+			t.Errorf("ParseBareItem(%q) with RFC9651=true: unexpected error: %v", input, err)
+		}
+	}
+}
+
+func TestDateRoundTrip(t *testing.T) {
+	date, err := NewDate(1659578233)
+	if err != nil {
+		t.Fatal(err)
+	}
+	serialized, err := SerDate(date)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if serialized != "@1659578233" {
+		t.Errorf("SerDate() = %q, want %q", serialized, "@1659578233")
+	}
+
+	s := serialized
+	parsed, err := ParseDate(&s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parsed != date {
+		t.Errorf("ParseDate(%q) = %v, want %v", serialized, parsed, date)
+	}
+}
+
+func TestDisplayStringRoundTrip(t *testing.T) {
+	ds, err := NewDisplayString("Hello 世界")
+	if err != nil {
+		t.Fatal(err)
+	}
+	serialized, err := SerDisplayString(ds)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := serialized
+	parsed, err := ParseDisplayString(&s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parsed != ds {
+		t.Errorf("ParseDisplayString(%q) = %q, want %q", serialized, parsed, ds)
+	}
+}
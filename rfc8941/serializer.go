@@ -0,0 +1,211 @@
+package rfc8941
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+)
+
+/*
+Serializer is an incremental, streaming alternative to
+TextSerialize/SerList/SerDictionary/SerItem for hot paths such as emitting
+a Signature-Input or Priority header per request: it writes directly to an
+io.Writer instead of building up the whole field value as concatenated Go
+strings, and reuses SerBareItem/SerKey/SerParameters for the actual bare
+item encoding.
+*/
+type Serializer struct {
+	w         io.Writer
+	buf       *bytes.Buffer
+	needComma bool
+	inInner   bool
+}
+
+// NewSerializer returns a Serializer that writes a single List or
+// Dictionary's worth of members to w.
+func NewSerializer(w io.Writer) *Serializer {
+	return &Serializer{w: w}
+}
+
+// NewSerializerBuffer returns a Serializer backed by an internal buffer;
+// use Bytes to retrieve what has been written so far.
+func NewSerializerBuffer() *Serializer {
+	buf := &bytes.Buffer{}
+	return &Serializer{w: buf, buf: buf}
+}
+
+// Bytes returns the bytes written so far. It only returns a non-nil slice
+// for a Serializer constructed with NewSerializerBuffer.
+func (s *Serializer) Bytes() []byte {
+	if s.buf == nil {
+		return nil
+	}
+	return s.buf.Bytes()
+}
+
+func (s *Serializer) writeSeparator() error {
+	if s.inInner {
+		return fmt.Errorf("rfc8941: Serializer: a top-level member was written inside an open Inner List (call EndInnerList first)")
+	}
+	if s.needComma {
+		if _, err := io.WriteString(s.w, ", "); err != nil {
+			return err
+		}
+	}
+	s.needComma = true
+	return nil
+}
+
+// WriteDictEntry writes one Dictionary member: key "=" item, eliding the
+// "=" and the bare item per Section 4.1.2 step 3.1.2 when value is a true
+// Boolean with no Parameters.
+func (s *Serializer) WriteDictEntry(key Key, value BareItem, params Parameters) error {
+	if err := s.writeSeparator(); err != nil {
+		return err
+	}
+	keyStr, err := SerKey(key)
+	if err != nil {
+		return err
+	}
+	if _, err := io.WriteString(s.w, keyStr); err != nil {
+		return err
+	}
+	if b, ok := value.(Boolean); ok && bool(b) && len(params) == 0 {
+		return nil
+	}
+	if _, err := io.WriteString(s.w, "="); err != nil {
+		return err
+	}
+	return s.writeItem(value, params)
+}
+
+// WriteDictInnerList writes one Dictionary member whose value is inner.
+func (s *Serializer) WriteDictInnerList(key Key, inner InnerList) error {
+	if err := s.writeSeparator(); err != nil {
+		return err
+	}
+	keyStr, err := SerKey(key)
+	if err != nil {
+		return err
+	}
+	if _, err := io.WriteString(s.w, keyStr+"="); err != nil {
+		return err
+	}
+	return s.writeInnerList(inner)
+}
+
+// WriteListItem writes one List member that is a bare Item.
+func (s *Serializer) WriteListItem(value BareItem, params Parameters) error {
+	if err := s.writeSeparator(); err != nil {
+		return err
+	}
+	return s.writeItem(value, params)
+}
+
+// WriteListInnerList writes one List member that is an Inner List.
+func (s *Serializer) WriteListInnerList(inner InnerList) error {
+	if err := s.writeSeparator(); err != nil {
+		return err
+	}
+	return s.writeInnerList(inner)
+}
+
+func (s *Serializer) writeItem(value BareItem, params Parameters) error {
+	itemStr, err := SerBareItem(toLegacyBareItem(value))
+	if err != nil {
+		return err
+	}
+	if _, err := io.WriteString(s.w, itemStr); err != nil {
+		return err
+	}
+	return s.writeParameters(params)
+}
+
+func (s *Serializer) writeInnerList(inner InnerList) error {
+	if _, err := io.WriteString(s.w, "("); err != nil {
+		return err
+	}
+	for i, it := range inner.Items {
+		if i > 0 {
+			if _, err := io.WriteString(s.w, " "); err != nil {
+				return err
+			}
+		}
+		if err := s.writeItem(it.Value, it.Parameters); err != nil {
+			return err
+		}
+	}
+	if _, err := io.WriteString(s.w, ")"); err != nil {
+		return err
+	}
+	return s.writeParameters(inner.Parameters)
+}
+
+func (s *Serializer) writeParameters(params Parameters) error {
+	paramsStr, err := SerParameters(params)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(s.w, paramsStr)
+	return err
+}
+
+// BeginInnerList opens an Inner List member, populated incrementally with
+// WriteInnerListItem calls and closed with EndInnerList. If key is
+// non-nil, the Inner List becomes a Dictionary member's value under that
+// key; otherwise it is a List member. BeginInnerList cannot be nested.
+func (s *Serializer) BeginInnerList(key *Key) error {
+	if s.inInner {
+		return fmt.Errorf("rfc8941: Serializer: BeginInnerList called while already inside an Inner List")
+	}
+	if s.needComma {
+		if _, err := io.WriteString(s.w, ", "); err != nil {
+			return err
+		}
+	}
+	s.needComma = true
+	if key != nil {
+		keyStr, err := SerKey(*key)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(s.w, keyStr+"="); err != nil {
+			return err
+		}
+	}
+	if _, err := io.WriteString(s.w, "("); err != nil {
+		return err
+	}
+	s.inInner = true
+	s.needComma = false
+	return nil
+}
+
+// WriteInnerListItem writes one Item into the Inner List most recently
+// opened with BeginInnerList.
+func (s *Serializer) WriteInnerListItem(value BareItem, params Parameters) error {
+	if !s.inInner {
+		return fmt.Errorf("rfc8941: Serializer: WriteInnerListItem called without an open Inner List (call BeginInnerList first)")
+	}
+	if s.needComma {
+		if _, err := io.WriteString(s.w, " "); err != nil {
+			return err
+		}
+	}
+	s.needComma = true
+	return s.writeItem(value, params)
+}
+
+// EndInnerList closes the Inner List most recently opened with
+// BeginInnerList, with params as the Inner List's own Parameters.
+func (s *Serializer) EndInnerList(params Parameters) error {
+	if !s.inInner {
+		return fmt.Errorf("rfc8941: Serializer: EndInnerList called without an open Inner List (call BeginInnerList first)")
+	}
+	if _, err := io.WriteString(s.w, ")"); err != nil {
+		return err
+	}
+	s.inInner = false
+	s.needComma = true
+	return s.writeParameters(params)
+}
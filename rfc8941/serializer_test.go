@@ -0,0 +1,76 @@
+package rfc8941
+
+import (
+	"testing"
+)
+
+func TestSerializerDictionary(t *testing.T) {
+	s := NewSerializerBuffer()
+	if err := s.WriteDictEntry("a", Integer(1), nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.WriteDictEntry("b", Boolean(true), nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.WriteDictInnerList("c", InnerList{Items: []Item{{Value: Token("x")}, {Value: Token("y")}}}); err != nil {
+		t.Fatal(err)
+	}
+	got := string(s.Bytes())
+	want := `a=1, b, c=(x y)`
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestSerializerBeginEndInnerList(t *testing.T) {
+	s := NewSerializerBuffer()
+	if err := s.WriteListItem(Integer(1), nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.BeginInnerList(nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.WriteInnerListItem(Token("x"), nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.WriteInnerListItem(Token("y"), nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.EndInnerList(nil); err != nil {
+		t.Fatal(err)
+	}
+	got := string(s.Bytes())
+	want := `1, (x y)`
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func BenchmarkTextSerializeDictionary(b *testing.B) {
+	var dict Dictionary
+	dict.Set("a", Integer(1), nil)
+	dict.Set("b", Boolean(true), nil)
+	dict.SetInnerList("c", InnerList{Items: []Item{{Value: Token("x")}, {Value: Token("y")}}})
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := TextSerialize(dict); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkSerializerDictionary(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		s := NewSerializerBuffer()
+		if err := s.WriteDictEntry("a", Integer(1), nil); err != nil {
+			b.Fatal(err)
+		}
+		if err := s.WriteDictEntry("b", Boolean(true), nil); err != nil {
+			b.Fatal(err)
+		}
+		if err := s.WriteDictInnerList("c", InnerList{Items: []Item{{Value: Token("x")}, {Value: Token("y")}}}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
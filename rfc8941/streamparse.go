@@ -0,0 +1,721 @@
+package rfc8941
+
+import (
+	"encoding/base64"
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/barweiss/go-tuple"
+	"github.com/jcbhmr/go-fetch/rfc7230"
+)
+
+/*
+Streaming equivalents of the Parse* functions in textparse.go, operating
+on a *Parser instead of a *string. These exist so that a large field
+value -- most notably a multi-kilobyte Byte Sequence, such as an HTTP
+Message Signatures signature -- never has to be materialized as a single
+string up front; ParseBinaryFromParser in particular decodes straight
+from the underlying io.Reader into a caller-supplied io.Writer. The
+*string functions in textparse.go are thin wrappers around these.
+*/
+
+func isIntegerOrDecimalStartByte(b byte) bool {
+	return b == '-' || (b >= '0' && b <= '9')
+}
+
+func isDigitByte(b byte) bool {
+	return b >= '0' && b <= '9'
+}
+
+func isLcalphaOrStarByte(b byte) bool {
+	return (b >= 'a' && b <= 'z') || b == '*'
+}
+
+func isAlphaOrStarByte(b byte) bool {
+	return (b >= 'a' && b <= 'z') || (b >= 'A' && b <= 'Z') || b == '*'
+}
+
+func isKeyCharByte(b byte) bool {
+	return (b >= 'a' && b <= 'z') || (b >= '0' && b <= '9') || b == '_' || b == '-' || b == '.'
+}
+
+func isOWSByte(b byte) bool {
+	return b == ' ' || b == '\t'
+}
+
+// ParseListFromParser is ParseList, reading from p instead of a *string.
+func ParseListFromParser(p *Parser) ([]tuple.T2[ItemOrInnerList, Parameters], error) {
+	// 1. Let members be an empty array.
+	members := []tuple.T2[ItemOrInnerList, Parameters]{}
+
+	// 2. While input_string is not empty:
+	for !p.AtEOF() {
+		// 1. Append the result of running Parsing an Item or Inner List with input_string to members.
+		res, err := ParseItemOrListFromParser(p)
+		if err != nil {
+			return nil, err
+		}
+		members = append(members, res)
+
+		// 2. Discard any leading OWS characters from input_string.
+		p.SkipWhile(isOWSByte)
+
+		// 3. If input_string is empty, return members.
+		if p.AtEOF() {
+			return members, nil
+		}
+
+		// 4. Consume the first character of input_string; if it is not ",", fail parsing.
+		if !p.Expect(',') {
+			return nil, newParseError(p.Pos(), ErrSyntax, "expected \",\"")
+		}
+
+		// 5. Discard any leading OWS characters from input_string.
+		p.SkipWhile(isOWSByte)
+
+		// 6. If input_string is empty, there is a trailing comma; fail parsing.
+		if p.AtEOF() {
+			return nil, newParseError(p.Pos(), ErrSyntax, "trailing comma")
+		}
+	}
+
+	// 3. No structured data has been found; return members (which is empty).
+	return members, nil
+}
+
+// ParseItemOrListFromParser is ParseItemOrList, reading from p instead
+// of a *string.
+func ParseItemOrListFromParser(p *Parser) (tuple.T2[ItemOrInnerList, Parameters], error) {
+	// 1. If the first character of input_string is "(", return the result of running Parsing an Inner List with input_string.
+	if c, ok := p.PeekByte(); ok && c == '(' {
+		res, err := ParseInnerListFromParser(p)
+		if err != nil {
+			return tuple.New2[ItemOrInnerList, Parameters](nil, nil), err
+		}
+		return tuple.New2[ItemOrInnerList, Parameters](res.V1, res.V2), nil
+	}
+
+	// 2. Return the result of running Parsing an Item with input_string.
+	return ParseItemFromParser(p)
+}
+
+// ParseInnerListFromParser is ParseInnerList, reading from p instead of
+// a *string.
+func ParseInnerListFromParser(p *Parser) (tuple.T2[legacyInnerList, Parameters], error) {
+	// 1. Consume the first character of input_string; if it is not "(", fail parsing.
+	if !p.Expect('(') {
+		return tuple.New2[legacyInnerList, Parameters](nil, nil), newParseError(p.Pos(), ErrSyntax, "expected \"(\"")
+	}
+
+	// 2. Let inner_list be an empty array.
+	innerList := []tuple.T2[any, Parameters]{}
+
+	// 3. While input_string is not empty:
+	for !p.AtEOF() {
+		// 1. Discard any leading SP characters from input_string.
+		p.SkipWhile(isSPByte)
+
+		// 2. If the first character of input_string is ")":
+		if c, ok := p.PeekByte(); ok && c == ')' {
+			// 1. Consume the first character of input_string.
+			p.ReadByte()
+
+			// 2. Let parameters be the result of running Parsing Parameters with input_string.
+			parameters, err := ParseParamFromParser(p)
+			if err != nil {
+				return tuple.New2[legacyInnerList, Parameters](nil, nil), err
+			}
+
+			// 3. Return the tuple (inner_list, parameters).
+			return tuple.New2(innerList, parameters), nil
+		}
+
+		// 3. Let item be the result of running Parsing an Item with input_string.
+		item, err := ParseItemFromParser(p)
+		if err != nil {
+			return tuple.New2[legacyInnerList, Parameters](nil, nil), err
+		}
+
+		// 4. Append item to inner_list.
+		innerList = append(innerList, item)
+
+		// 5. If the first character of input_string is not SP or ")", fail parsing.
+		if c, ok := p.PeekByte(); !ok || (c != ' ' && c != ')') {
+			return tuple.New2[legacyInnerList, Parameters](nil, nil), newParseError(p.Pos(), ErrSyntax, "expected SP or \")\"")
+		}
+	}
+
+	// 4. The end of the Inner List was not found; fail parsing.
+	return tuple.New2[legacyInnerList, Parameters](nil, nil), newParseError(p.Pos(), ErrEOF, "unterminated inner list")
+}
+
+func isSPByte(b byte) bool {
+	return b == ' '
+}
+
+// ParseDictionaryFromParser is ParseDictionary, reading from p instead
+// of a *string.
+func ParseDictionaryFromParser(p *Parser) (legacyDictionary, error) {
+	// 1. Let dictionary be an empty, ordered map.
+	dictionary := legacyDictionary{}
+
+	// 2. While input_string is not empty:
+	for !p.AtEOF() {
+		// 1. Let this_key be the result of running Parsing a Key with input_string.
+		thisKey, err := ParseKeyFromParser(p)
+		if err != nil {
+			return nil, err
+		}
+
+		var member tuple.T2[any, Parameters]
+
+		// 2. If the first character of input_string is "=":
+		if c, ok := p.PeekByte(); ok && c == '=' {
+			// 1. Consume the first character of input_string.
+			p.ReadByte()
+
+			// 2. Let member be the result of running Parsing an Item or Inner List with input_string.
+			member, err = ParseItemOrListFromParser(p)
+			if err != nil {
+				return nil, err
+			}
+		} else {
+			// 3. Otherwise:
+
+			// 1. Let value be Boolean true.
+			var value any = true
+
+			// 2. Let parameters be the result of running Parsing Parameters with input_string.
+			parameters, err := ParseParamFromParser(p)
+			if err != nil {
+				return nil, err
+			}
+
+			// 3. Let member be the tuple (value, parameters).
+			member = tuple.New2(value, parameters)
+		}
+
+		// 4. If dictionary already contains a key this_key (comparing character for character), overwrite its value with member.
+		replaced := false
+		for i, keyValue := range dictionary {
+			if keyValue.V1 == thisKey {
+				dictionary[i] = tuple.New2(thisKey, member)
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			// 5. Otherwise, append key this_key with value member to dictionary.
+			dictionary = append(dictionary, tuple.New2(thisKey, member))
+		}
+
+		// 6. Discard any leading OWS characters from input_string.
+		p.SkipWhile(isOWSByte)
+
+		// 7. If input_string is empty, return dictionary.
+		if p.AtEOF() {
+			return dictionary, nil
+		}
+
+		// 8. Consume the first character of input_string; if it is not ",", fail parsing.
+		if !p.Expect(',') {
+			return nil, newParseError(p.Pos(), ErrSyntax, "expected \",\"")
+		}
+
+		// 9. Discard any leading OWS characters from input_string.
+		p.SkipWhile(isOWSByte)
+
+		// 10. If input_string is empty, there is a trailing comma; fail parsing.
+		if p.AtEOF() {
+			return nil, newParseError(p.Pos(), ErrSyntax, "trailing comma")
+		}
+	}
+
+	// 3. No structured data has been found; return dictionary (which is empty).
+	return dictionary, nil
+}
+
+// ParseItemFromParser is ParseItem, reading from p instead of a
+// *string.
+func ParseItemFromParser(p *Parser) (tuple.T2[any, Parameters], error) {
+	// 1. Let bare_item be the result of running Parsing a Bare Item with input_string.
+	bareItem, err := ParseBareItemFromParser(p)
+	if err != nil {
+		return tuple.New2[any, Parameters](nil, nil), err
+	}
+
+	// 2. Let parameters be the result of running Parsing Parameters with input_string.
+	parameters, err := ParseParamFromParser(p)
+	if err != nil {
+		return tuple.New2[any, Parameters](nil, nil), err
+	}
+
+	// 3. Return the tuple (bare_item, parameters).
+	return tuple.New2(bareItem, parameters), nil
+}
+
+// ParseBareItemFromParser is ParseBareItem, reading from p instead of a
+// *string.
+func ParseBareItemFromParser(p *Parser) (any, error) {
+	c, ok := p.PeekByte()
+	if !ok {
+		return nil, newParseError(p.Pos(), ErrEOF, "empty bare item")
+	}
+
+	// 1. If the first character of input_string is a "-" or a DIGIT, return the result of running Parsing an Integer or Decimal with input_string.
+	if isIntegerOrDecimalStartByte(c) {
+		return ParseNumberFromParser(p)
+	} else if c == '"' {
+		// 2. If the first character of input_string is a DQUOTE, return the result of running Parsing a String with input_string.
+		return ParseStringFromParser(p)
+	} else if isLcalphaOrStarByte(c) {
+		// 3. If the first character of input_string is an ALPHA or "*", return the result of running Parsing a Token with input_string.
+		return ParseTokenFromParser(p)
+	} else if c == ':' {
+		// 4. If the first character of input_string is ":", return the result of running Parsing a Byte Sequence with input_string.
+		var buf strings.Builder
+		if _, err := ParseBinaryFromParser(p, &buf, ParseOptions{}); err != nil {
+			return nil, err
+		}
+		return []byte(buf.String()), nil
+	} else if c == '?' {
+		// 5. If the first character of input_string is "?", return the result of running Parsing a Boolean with input_string.
+		return ParseBooleanFromParser(p)
+	} else if RFC9651 && c == '@' {
+		// RFC 9651 Section 4.2.3.1 adds: if the first character of
+		// input_string is "@", return the result of running Parsing a
+		// Date with input_string.
+		return ParseDateFromParser(p)
+	} else if RFC9651 && c == '%' {
+		if pk, ok := p.Peek2(); ok && pk[1] == '"' {
+			// RFC 9651 Section 4.2.3.1 adds: if the first two
+			// characters of input_string are "%" followed by DQUOTE,
+			// return the result of running Parsing a Display String
+			// with input_string.
+			return ParseDisplayStringFromParser(p)
+		}
+		return nil, newParseError(p.Pos(), ErrSyntax, "unrecognized bare item type")
+	} else {
+		// 6. Otherwise, the item type is unrecognized; fail parsing.
+		return nil, newParseError(p.Pos(), ErrSyntax, "unrecognized bare item type")
+	}
+}
+
+// ParseParamFromParser is ParseParam, reading from p instead of a
+// *string.
+func ParseParamFromParser(p *Parser) (Parameters, error) {
+	// 1. Let parameters be an empty, ordered map.
+	parameters := []tuple.T2[string, any]{}
+
+	// 2. While input_string is not empty:
+	for !p.AtEOF() {
+		// 1. If the first character of input_string is not ";", exit the loop.
+		c, _ := p.PeekByte()
+		if c != ';' {
+			break
+		}
+
+		// 2. Consume the ";" character from the beginning of input_string.
+		p.ReadByte()
+
+		// 3. Discard any leading SP characters from input_string.
+		p.SkipWhile(isSPByte)
+
+		// 4. Let param_key be the result of running Parsing a Key with input_string.
+		paramKey, err := ParseKeyFromParser(p)
+		if err != nil {
+			return nil, err
+		}
+
+		// 5. Let param_value be Boolean true.
+		var paramValue any = true
+
+		// 6. If the first character of input_string is "=":
+		if c, ok := p.PeekByte(); ok && c == '=' {
+			// 1. Consume the "=" character at the beginning of input_string.
+			p.ReadByte()
+			// 2. Let param_value be the result of running Parsing a Bare Item with input_string.
+			paramValue, err = ParseBareItemFromParser(p)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		// 7. If parameters already contains a key param_key (comparing character for character), overwrite its value with param_value.
+		index := -1
+		for i, pm := range parameters {
+			if pm.V1 == paramKey {
+				index = i
+				break
+			}
+		}
+		if index != -1 {
+			parameters[index] = tuple.New2(paramKey, paramValue)
+		} else {
+			// 8. Otherwise, append key param_key with value param_value to parameters.
+			parameters = append(parameters, tuple.New2(paramKey, paramValue))
+		}
+	}
+
+	// 3. Return parameters.
+	return parameters, nil
+}
+
+// ParseKeyFromParser is ParseKey, reading from p instead of a *string.
+func ParseKeyFromParser(p *Parser) (Key, error) {
+	// 1. If the first character of input_string is not lcalpha or "*", fail parsing.
+	c, ok := p.PeekByte()
+	if !ok || !isLcalphaOrStarByte(c) {
+		return "", newParseError(p.Pos(), ErrSyntax, "expected lcalpha or \"*\"")
+	}
+
+	// 2. Let output_string be an empty string.
+	var outputString strings.Builder
+
+	// 3. While input_string is not empty:
+	for !p.AtEOF() {
+		// 1. If the first character of input_string is not one of lcalpha, DIGIT, "_", "-", ".", or "*", return output_string.
+		c, _ := p.PeekByte()
+		if !isKeyCharByte(c) && c != '*' {
+			return outputString.String(), nil
+		}
+		// 2. Let char be the result of consuming the first character of input_string.
+		// 3. Append char to output_string.
+		p.ReadByte()
+		outputString.WriteByte(c)
+	}
+	// 4. Return output_string.
+	return outputString.String(), nil
+}
+
+// ParseNumberFromParser is ParseNumber, reading from p instead of a
+// *string.
+func ParseNumberFromParser(p *Parser) (IntegerOrDecimal, error) {
+	// 1. Let type be "integer".
+	type_ := "integer"
+	// 2. Let sign be 1.
+	sign := 1
+	// 3. Let input_number be an empty string.
+	var inputNumber strings.Builder
+
+	// 4. If the first character of input_string is "-", consume it and set sign to -1.
+	if c, ok := p.PeekByte(); ok && c == '-' {
+		p.ReadByte()
+		sign = -1
+	}
+
+	// 5. If input_string is empty, there is an empty integer; fail parsing.
+	c, ok := p.PeekByte()
+	if !ok {
+		return nil, newParseError(p.Pos(), ErrEOF, "empty number")
+	}
+
+	// 6. If the first character of input_string is not a DIGIT, fail parsing.
+	if !isDigitByte(c) {
+		return nil, newParseError(p.Pos(), ErrSyntax, "expected a digit")
+	}
+
+	// 7. While input_string is not empty:
+	for !p.AtEOF() {
+		// 1. Let char be the result of consuming the first character of input_string.
+		char, _ := p.PeekByte()
+
+		// 2. If char is a DIGIT, append it to input_number.
+		if isDigitByte(char) {
+			p.ReadByte()
+			inputNumber.WriteByte(char)
+		} else if char == '.' {
+			// 3. Else, if type is "integer" and char is ".":
+
+			// 1. If input_number contains more than 12 characters, fail parsing.
+			if inputNumber.Len() > 12 {
+				return nil, newParseError(p.Pos(), ErrIntegerRange, "too many digits before \".\"")
+			}
+			// 2. Otherwise, append char to input_number and set type to "decimal".
+			p.ReadByte()
+			inputNumber.WriteByte(char)
+			type_ = "decimal"
+		} else {
+			// 4. Otherwise, prepend char to input_string, and exit the loop.
+			break
+		}
+
+		// 5. If type is "integer" and input_number contains more than 15 characters, fail parsing.
+		if type_ == "integer" && inputNumber.Len() > 15 {
+			return nil, newParseError(p.Pos(), ErrIntegerRange, "integer too long")
+		}
+
+		// 6. If type is "decimal" and input_number contains more than 16 characters, fail parsing.
+		if type_ == "decimal" && inputNumber.Len() > 16 {
+			return nil, newParseError(p.Pos(), ErrIntegerRange, "decimal too long")
+		}
+	}
+
+	// 8. If type is "integer":
+	var outputNumber any
+	numStr := inputNumber.String()
+	if type_ == "integer" {
+		// 1. Parse input_number as an integer and let output_number be the product of the result and sign.
+		num, err := strconv.ParseInt(numStr, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		outputNumber = num * int64(sign)
+	} else {
+		// 9. Otherwise:
+
+		// 1. If the final character of input_number is ".", fail parsing.
+		if numStr[len(numStr)-1] == '.' {
+			return nil, newParseError(p.Pos(), ErrSyntax, "decimal ends with \".\"")
+		}
+
+		// 2. If the number of characters after "." in input_number is greater than three, fail parsing.
+		if len(numStr)-strings.Index(numStr, ".")-1 > 3 {
+			return nil, newParseError(p.Pos(), ErrDecimalPrecision, "too many digits after \".\"")
+		}
+
+		// 3. Parse input_number as a decimal number and let output_number be the product of the result and sign.
+		num, err := strconv.ParseFloat(numStr, 64)
+		if err != nil {
+			return nil, err
+		}
+		outputNumber = num * float64(sign)
+	}
+
+	// 10. Return output_number.
+	return outputNumber, nil
+}
+
+// ParseStringFromParser is ParseString, reading from p instead of a
+// *string.
+func ParseStringFromParser(p *Parser) (string, error) {
+	// 1. Let output_string be an empty string.
+	var outputString strings.Builder
+
+	// 2. If the first character of input_string is not DQUOTE, fail parsing.
+	if !p.Expect('"') {
+		return "", newParseError(p.Pos(), ErrSyntax, "expected DQUOTE")
+	}
+
+	// 4. While input_string is not empty:
+	for !p.AtEOF() {
+		// 1. Let char be the result of consuming the first character of input_string.
+		char, _ := p.ReadByte()
+
+		// 2. If char is a backslash ("\"):
+		if char == '\\' {
+			// 1. If input_string is now empty, fail parsing.
+			nextChar, err := p.ReadByte()
+			if err != nil {
+				return "", newParseError(p.Pos(), ErrEOF, "unterminated escape")
+			}
+
+			// 3. If next_char is not DQUOTE or "\", fail parsing.
+			if nextChar != '"' && nextChar != '\\' {
+				return "", newParseError(p.Pos(), ErrBadEscape, "invalid escape")
+			}
+
+			// 4. Append next_char to output_string.
+			outputString.WriteByte(nextChar)
+		} else if char == '"' {
+			// 3. Else, if char is DQUOTE, return output_string.
+			return outputString.String(), nil
+		} else if char <= 0x1f || char >= 0x7f {
+			// 4. Else, if char is in the range %x00-1f or %x7f-ff (i.e., it is not in VCHAR or SP), fail parsing.
+			return "", newParseError(p.Pos(), ErrSyntax, "invalid character in string")
+		} else {
+			// 5. Else, append char to output_string.
+			outputString.WriteByte(char)
+		}
+	}
+
+	// 5. Reached the end of input_string without finding a closing DQUOTE; fail parsing.
+	return "", newParseError(p.Pos(), ErrEOF, "unterminated string")
+}
+
+// ParseTokenFromParser is ParseToken, reading from p instead of a
+// *string.
+func ParseTokenFromParser(p *Parser) (Token, error) {
+	// 1. If the first character of input_string is not ALPHA or "*", fail parsing.
+	c, ok := p.PeekByte()
+	if !ok || !isAlphaOrStarByte(c) {
+		return "", newParseError(p.Pos(), ErrSyntax, "expected ALPHA or \"*\"")
+	}
+
+	// 2. Let output_string be an empty string.
+	var outputString strings.Builder
+
+	// 3. While input_string is not empty:
+	for !p.AtEOF() {
+		// 1. If the first character of input_string is not in tchar, ":", or "/", return output_string.
+		c, _ := p.PeekByte()
+		if !rfc7230.IsTChar(c) && c != ':' && c != '/' {
+			return Token(outputString.String()), nil
+		}
+
+		// 2. Let char be the result of consuming the first character of input_string.
+		p.ReadByte()
+
+		// 3. Append char to output_string.
+		outputString.WriteByte(c)
+	}
+
+	// 4. Return output_string.
+	return Token(outputString.String()), nil
+}
+
+// ParseBinaryFromParser is ParseBinary, reading from p instead of a
+// *string and decoding directly into w with base64.NewDecoder, instead
+// of allocating an intermediate string for the whole body. It returns
+// the number of decoded bytes written to w.
+func ParseBinaryFromParser(p *Parser, w io.Writer, opts ParseOptions) (int64, error) {
+	// 1. If the first character of input_string is not ":", fail parsing.
+	if !p.Expect(':') {
+		return 0, newParseError(p.Pos(), ErrSyntax, "expected \":\"")
+	}
+
+	// 2-6. Consume content up to but not including the next ":",
+	// validating that every character is in the base64 alphabet, and
+	// tracking trailing "=" so leniency can recompute correct padding.
+	var b64Content strings.Builder
+	for {
+		c, err := p.ReadByte()
+		if err != nil {
+			return 0, newParseError(p.Pos(), ErrEOF, "unterminated byte sequence")
+		}
+		if c == ':' {
+			break
+		}
+		if !isBase64CharByte(c) {
+			return 0, newParseError(p.Pos(), ErrSyntax, "invalid base64 character")
+		}
+		b64Content.WriteByte(c)
+	}
+
+	// 7. Let binary_content be the result of base64-decoding [RFC4648] b64_content, synthesizing padding if necessary. If base64 decoding fails, parsing fails.
+	content := b64Content.String()
+	var dec io.Reader
+	if opts.StrictBase64 {
+		dec = base64.NewDecoder(base64.StdEncoding.Strict(), strings.NewReader(content))
+	} else {
+		trimmed := strings.TrimRight(content, "=")
+		padded := trimmed + strings.Repeat("=", (4-len(trimmed)%4)%4)
+		dec = base64.NewDecoder(base64.StdEncoding, strings.NewReader(padded))
+	}
+	n, err := io.Copy(w, dec)
+	if err != nil {
+		return n, newParseErrorWrap(p.Pos(), ErrSyntax, err)
+	}
+
+	// 8. Return binary_content.
+	return n, nil
+}
+
+func isBase64CharByte(b byte) bool {
+	return (b >= 'A' && b <= 'Z') || (b >= 'a' && b <= 'z') || (b >= '0' && b <= '9') || b == '+' || b == '/' || b == '='
+}
+
+// ParseBooleanFromParser is ParseBoolean, reading from p instead of a
+// *string.
+func ParseBooleanFromParser(p *Parser) (bool, error) {
+	// 1. If the first character of input_string is not "?", fail parsing.
+	if !p.Expect('?') {
+		return false, newParseError(p.Pos(), ErrSyntax, "expected \"?\"")
+	}
+
+	// 3. If the first character of input_string matches "1", discard the first character, and return true.
+	if p.Expect('1') {
+		return true, nil
+	}
+
+	// 4. If the first character of input_string matches "0", discard the first character, and return false.
+	if p.Expect('0') {
+		return false, nil
+	}
+
+	// 5. No value has matched; fail parsing.
+	return false, newParseError(p.Pos(), ErrSyntax, "expected \"0\" or \"1\"")
+}
+
+// ParseDateFromParser is ParseDate, reading from p instead of a
+// *string.
+func ParseDateFromParser(p *Parser) (Date, error) {
+	// 1. If the first character of input_string is not "@", fail parsing.
+	if !p.Expect('@') {
+		return 0, newParseError(p.Pos(), ErrSyntax, "expected \"@\"")
+	}
+
+	// 2-3. Let output_integer be the result of running Parsing an Integer or Decimal with input_string; if it is not an Integer, fail parsing.
+	outputNumber, err := ParseNumberFromParser(p)
+	if err != nil {
+		return 0, err
+	}
+	outputInteger, ok := outputNumber.(int64)
+	if !ok {
+		return 0, newParseError(p.Pos(), ErrSyntax, "date is not an integer")
+	}
+
+	// 5. Return output_integer.
+	return Date(outputInteger), nil
+}
+
+// ParseDisplayStringFromParser is ParseDisplayString, reading from p
+// instead of a *string.
+func ParseDisplayStringFromParser(p *Parser) (DisplayString, error) {
+	// 1. If the first two characters of input_string are not "%" followed by DQUOTE, fail parsing.
+	if !p.Expect('%') || !p.Expect('"') {
+		return "", newParseError(p.Pos(), ErrSyntax, "expected \"%%\\\"\"")
+	}
+
+	// 3. Let byte_array be an empty byte array.
+	var byteArray []byte
+
+	// 4. While input_string is not empty:
+	for !p.AtEOF() {
+		// 1. Let char be the result of removing the first character of input_string.
+		char, _ := p.ReadByte()
+
+		// 2. If char is a DQUOTE, return the result of decoding byte_array as a UTF-8 string, failing parsing if byte_array is not a valid UTF-8 string.
+		if char == '"' {
+			if !utf8.Valid(byteArray) {
+				return "", newParseError(p.Pos(), ErrBadEscape, "display string is not valid UTF-8")
+			}
+			return DisplayString(byteArray), nil
+		}
+
+		// 3. If char is "%", then:
+		if char == '%' {
+			// 1. If the first two characters of input_string are not lowercase hexadecimal digits, fail parsing.
+			hi, ok1 := p.ReadByte()
+			if ok1 != nil || !isLowerHexDigit(hi) {
+				return "", newParseError(p.Pos(), ErrBadEscape, "expected two lowercase hex digits")
+			}
+			lo, ok2 := p.ReadByte()
+			if ok2 != nil || !isLowerHexDigit(lo) {
+				return "", newParseError(p.Pos(), ErrBadEscape, "expected two lowercase hex digits")
+			}
+
+			// 2. Let octet be the result of decoding the two hex digits.
+			octet, err := hexDigitsToByte(hi, lo)
+			if err != nil {
+				return "", err
+			}
+
+			// 3. Append octet to byte_array.
+			byteArray = append(byteArray, octet)
+			continue
+		}
+
+		// 4. Else: If char is outside %x20-7E, fail parsing.
+		if char < 0x20 || char > 0x7e {
+			return "", newParseError(p.Pos(), ErrSyntax, "invalid character in display string")
+		}
+		// Append char to byte_array.
+		byteArray = append(byteArray, char)
+	}
+
+	// 5. Reached the end of input_string without finding a closing DQUOTE; fail parsing.
+	return "", newParseError(p.Pos(), ErrEOF, "unterminated display string")
+}
@@ -0,0 +1,47 @@
+package rfc8941
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestParseBinaryFromParserStreams(t *testing.T) {
+	// "pumpkin" base64-encodes to "cHVtcGtpbg==".
+	p := NewParser(strings.NewReader(`:cHVtcGtpbg==:` + "TRAILING"))
+	var buf bytes.Buffer
+	n, err := ParseBinaryFromParser(p, &buf, ParseOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != int64(buf.Len()) {
+		t.Errorf("n = %d, want %d", n, buf.Len())
+	}
+	if buf.String() != "pumpkin" {
+		t.Errorf("buf.String() = %q, want %q", buf.String(), "pumpkin")
+	}
+	if p.Pos() != len(`:cHVtcGtpbg==:`) {
+		t.Errorf("p.Pos() = %d, want %d", p.Pos(), len(`:cHVtcGtpbg==:`))
+	}
+}
+
+func TestParseItemFromParserMatchesParseItem(t *testing.T) {
+	const input = `text/html;q=0.8, leftover`
+	wantStr := input
+	want, err := ParseItem(&wantStr)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p := NewParser(strings.NewReader(input))
+	got, err := ParseItemFromParser(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.V1 != want.V1 {
+		t.Errorf("bare item = %#+v, want %#+v", got.V1, want.V1)
+	}
+	if p.Pos() != len(input)-len(wantStr) {
+		t.Errorf("p.Pos() = %d, want %d", p.Pos(), len(input)-len(wantStr))
+	}
+}
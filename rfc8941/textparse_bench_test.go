@@ -0,0 +1,28 @@
+package rfc8941
+
+import "testing"
+
+// BenchmarkParseList and BenchmarkParseDictionary track allocations for the
+// Parser-backed parsers in streamparse.go (byte predicates instead of
+// per-call regexp, a cursor over the input instead of repeated string
+// concatenation) that ParseList/ParseDictionary are thin wrappers around.
+
+func BenchmarkParseList(b *testing.B) {
+	const input = `text/html;q=0.8, text/plain;q=0.5, application/json, */*;q=0.1`
+	for i := 0; i < b.N; i++ {
+		s := input
+		if _, err := ParseList(&s); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkParseDictionary(b *testing.B) {
+	const input = `a=1, b=2;x=1;y=2, c="hello world", d=(1 2 3), e=?1, f=:cGluZw==:`
+	for i := 0; i < b.N; i++ {
+		s := input
+		if _, err := ParseDictionary(&s); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -26,6 +26,10 @@ var textParseTests = []struct {
 	{[]byte(`text/html;q=1.0`), "list", false},
 	{[]byte(`text/html  ,  text/plain;  q=0.5;  charset=utf-8`), "list", false},
 	{[]byte(`a=1, b;foo=9, c=3`), "dictionary", false},
+	// A Decimal with exactly three fractional digits is the boundary Section
+	// 4.2.4 step 9.2 allows; the checked-in httpwg number.json fixture
+	// doesn't happen to exercise it.
+	{[]byte(`text/html;q=0.123`), "list", false},
 }
 
 func TestTextParse(t *testing.T) {
@@ -45,3 +49,61 @@ func TestTextParse(t *testing.T) {
 		})
 	}
 }
+
+func TestParseNumberThreeFractionalDigits(t *testing.T) {
+	// Exactly three fractional digits must round-trip: SerDecimal(1.234)
+	// serializes to "1.234", so parsing "1.234" and "0.123" back must
+	// succeed rather than being rejected as "too many digits after '.'".
+	for _, tt := range []struct {
+		input string
+		want  float64
+	}{
+		{"1.234", 1.234},
+		{"0.123", 0.123},
+	} {
+		s := tt.input
+		got, err := ParseNumber(&s)
+		if err != nil {
+			t.Fatalf("ParseNumber(%q): unexpected error: %v", tt.input, err)
+		}
+		if got != tt.want {
+			t.Errorf("ParseNumber(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestParseBinaryLenientPadding(t *testing.T) {
+	// "pumpkin" base64-encodes to "cHVtcGtpbg==", i.e. "cHVtcGtpbg" with
+	// the "==" padding omitted.
+	for _, input := range []string{`:cHVtcGtpbg:`, `:cHVtcGtpbg==:`} {
+		s := input
+		got, err := ParseBinary(&s)
+		if err != nil {
+			t.Fatalf("ParseBinary(%q): unexpected error: %v", input, err)
+		}
+		if string(got) != "pumpkin" {
+			t.Errorf("ParseBinary(%q) = %q, want %q", input, got, "pumpkin")
+		}
+
+		s = input
+		if _, err := ParseBinaryWithOptions(&s, ParseOptions{StrictBase64: true}); input == `:cHVtcGtpbg:` && err == nil {
+			t.Errorf("ParseBinaryWithOptions(%q, StrictBase64) = nil error, want an error for missing padding", input)
+		}
+	}
+}
+
+func TestParseBinaryNonZeroPadBits(t *testing.T) {
+	// "AC==" is fully "="-padded, but the bottom 4 bits of its second
+	// sextet ("C" = 0b000010) are nonzero even though they don't affect
+	// the single decoded output byte.
+	input := `:AC==:`
+	s := input
+	if _, err := ParseBinary(&s); err != nil {
+		t.Errorf("ParseBinary(%q): unexpected error: %v", input, err)
+	}
+
+	s = input
+	if _, err := ParseBinaryWithOptions(&s, ParseOptions{StrictBase64: true}); err == nil {
+		t.Errorf("ParseBinaryWithOptions(%q, StrictBase64) = nil error, want an error for non-zero pad bits", input)
+	}
+}
@@ -7,11 +7,250 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/barweiss/go-tuple"
 	"github.com/jcbhmr/go-fetch/rfc5234"
 	"github.com/jcbhmr/go-fetch/rfc7230"
 	"golang.org/x/exp/utf8string"
 )
 
+/*
+# 4.1. Serializing Structured Fields
+
+https://httpwg.org/specs/rfc8941.html#text-serialize
+*/
+
+// An ordered Dictionary, as produced by ParseDictionary and consumed by
+// SerDictionary: each member has a member_key and a tuple value of
+// (member_value, parameters).
+type legacyDictionary = []tuple.T2[string, tuple.T2[ItemOrInnerList, Parameters]]
+
+// An ordered List of (member_value, parameters) tuples, as produced by
+// ParseList and consumed by SerList.
+type legacyList = []tuple.T2[ItemOrInnerList, Parameters]
+
+// Given a structure defined in this specification, return an ASCII string suitable for use in an HTTP field value.
+//
+// https://httpwg.org/specs/rfc8941.html#text-serialize
+func TextSerialize(input any) ([]byte, error) {
+	// The typed List, Dictionary, and Item (see typed.go) are converted to
+	// their legacy any-shaped equivalents up front, so the rest of this
+	// function only has to deal with one representation of each structure.
+	switch v := input.(type) {
+	case List:
+		input = v.toLegacy()
+	case Dictionary:
+		input = v.toLegacy()
+	case Item:
+		input = v.toLegacy()
+	}
+
+	// 1. If the structure is a Dictionary or List and its value is empty (i.e., it has no members), do not serialize the field at all (i.e., omit both the field-name and field-value).
+	if v, ok := input.(legacyDictionary); ok && len(v) == 0 {
+		return nil, nil
+	}
+	if v, ok := input.(legacyList); ok && len(v) == 0 {
+		return nil, nil
+	}
+
+	var outputString string
+	// 2. If the structure is a List, let output_string be the result of running Serializing a List (Section 4.1.1) with the structure.
+	if v, ok := input.(legacyList); ok {
+		res, err := SerList(v)
+		if err != nil {
+			return nil, err
+		}
+		outputString = res
+	} else if v, ok := input.(legacyDictionary); ok {
+		// 3. Else, if the structure is a Dictionary, let output_string be the result of running Serializing a Dictionary (Section 4.1.2) with the structure.
+		res, err := SerDictionary(v)
+		if err != nil {
+			return nil, err
+		}
+		outputString = res
+	} else if v, ok := input.(tuple.T2[any, Parameters]); ok {
+		// 4. Else, if the structure is an Item, let output_string be the result of running Serializing an Item (Section 4.1.3) with the structure.
+		res, err := SerItem(v.V1, v.V2)
+		if err != nil {
+			return nil, err
+		}
+		outputString = res
+	} else {
+		// 5. Else, fail serialization.
+		return nil, fmt.Errorf("serialization failed: %#+v", input)
+	}
+
+	// 6. Return output_string converted into an array of bytes, using ASCII encoding [RFC0020].
+	return []byte(outputString), nil
+}
+
+/*
+# 4.1.1. Serializing a List
+
+https://httpwg.org/specs/rfc8941.html#ser-list
+*/
+
+// Given an array of (member_value, parameters) tuples as input_list, return an ASCII string suitable for use in an HTTP field value.
+//
+// https://httpwg.org/specs/rfc8941.html#ser-list
+func SerList(inputList legacyList) (string, error) {
+	// 1. Let output be an empty string.
+	output := ""
+	// 2. For each (member_value, parameters) of input_list:
+	for i, memberValueParameters := range inputList {
+		memberValue := memberValueParameters.V1
+		parameters := memberValueParameters.V2
+
+		// 1. If member_value is an array, append the result of running Serializing an Inner List (Section 4.1.1.1) with (member_value, parameters) to output.
+		if v, ok := memberValue.(legacyInnerList); ok {
+			innerListStr, err := SerInnerList(v, parameters)
+			if err != nil {
+				return "", err
+			}
+			output += innerListStr
+		} else {
+			// 2. Otherwise, append the result of running Serializing an Item (Section 4.1.3) with (member_value, parameters) to output.
+			itemStr, err := SerItem(memberValue, parameters)
+			if err != nil {
+				return "", err
+			}
+			output += itemStr
+		}
+
+		// 3. If more member_values remain in input_list:
+		if i < len(inputList)-1 {
+			// 1. Append "," to output.
+			output += ","
+			// 2. Append a single SP to output.
+			output += " "
+		}
+	}
+	// 3. Return output.
+	return output, nil
+}
+
+/*
+# 4.1.1.1. Serializing an Inner List
+
+https://httpwg.org/specs/rfc8941.html#ser-innerlist
+*/
+
+// Given an array of (member_value, parameters) tuples as inner_list, and parameters as list_parameters, return an ASCII string suitable for use in an HTTP field value.
+//
+// https://httpwg.org/specs/rfc8941.html#ser-innerlist
+func SerInnerList(innerList legacyInnerList, listParameters Parameters) (string, error) {
+	// 1. Let output be the string "(".
+	output := "("
+	// 2. For each (member_value, parameters) of inner_list:
+	for i, memberValueParameters := range innerList {
+		memberValue := memberValueParameters.V1
+		parameters := memberValueParameters.V2
+
+		// 1. Append the result of running Serializing an Item (Section 4.1.3) with (member_value, parameters) to output.
+		itemStr, err := SerItem(memberValue, parameters)
+		if err != nil {
+			return "", err
+		}
+		output += itemStr
+
+		// 2. If more values remain in inner_list, append a single SP to output.
+		if i < len(innerList)-1 {
+			output += " "
+		}
+	}
+
+	// 3. Append ")" to output.
+	output += ")"
+	// 4. Append the result of running Serializing Parameters (Section 4.1.1.2) with list_parameters to output.
+	listParametersStr, err := SerParameters(listParameters)
+	if err != nil {
+		return "", err
+	}
+	output += listParametersStr
+	// 5. Return output.
+	return output, nil
+}
+
+/*
+# 4.1.1.2. Serializing Parameters
+
+https://httpwg.org/specs/rfc8941.html#ser-params
+*/
+
+// Given an ordered Dictionary as input_parameters (each member having a param_key and a param_value), return an ASCII string suitable for use in an HTTP field value.
+//
+// https://httpwg.org/specs/rfc8941.html#ser-params
+func SerParameters(inputParameters Parameters) (string, error) {
+	// 1. Let output be an empty string.
+	output := ""
+	// 2. For each param_key with a value of param_value in input_parameters:
+	for _, paramKeyValue := range inputParameters {
+		paramKey := paramKeyValue.V1
+		paramValue := paramKeyValue.V2
+
+		// 3. Append ";" to output.
+		output += ";"
+
+		// 4. Append the result of running Serializing a Key (Section 4.1.1.3) with param_key to output.
+		paramKeyStr, err := SerKey(paramKey)
+		if err != nil {
+			return "", err
+		}
+		output += paramKeyStr
+
+		// 5. If param_value is not Boolean true:
+		if value, ok := paramValue.(bool); !ok || !value {
+			// 1. Append "=" to output.
+			output += "="
+			// 2. Append the result of running Serializing a bare Item (Section 4.1.3.1) with param_value to output.
+			paramValueStr, err := SerBareItem(paramValue)
+			if err != nil {
+				return "", err
+			}
+			output += paramValueStr
+		}
+	}
+
+	// 3. Return output.
+	return output, nil
+}
+
+/*
+# 4.1.1.3. Serializing a Key
+
+https://httpwg.org/specs/rfc8941.html#ser-key
+*/
+
+// Given a key as input_key, return an ASCII string suitable for use in an HTTP field value.
+//
+// https://httpwg.org/specs/rfc8941.html#ser-key
+func SerKey(inputKey Key) (string, error) {
+	// 1. Convert input_key into a sequence of ASCII characters; if conversion fails, fail serialization.
+	value := string(inputKey)
+	if !utf8string.NewString(value).IsASCII() {
+		return "", fmt.Errorf("serialization failed: %#+v", inputKey)
+	}
+
+	// 2. If input_key contains characters not in lcalpha, DIGIT, "_", "-", ".", or "*", fail serialization.
+	for i := 0; i < len(value); i++ {
+		b := value[i]
+		if !((b >= 'a' && b <= 'z') || rfc5234.IsDIGIT(b) || b == '_' || b == '-' || b == '.' || b == '*') {
+			return "", fmt.Errorf("serialization failed: %#+v", inputKey)
+		}
+	}
+
+	// 3. If the first character of input_key is not lcalpha or "*", fail serialization.
+	if !((value[0] >= 'a' && value[0] <= 'z') || value[0] == '*') {
+		return "", fmt.Errorf("serialization failed: %#+v", inputKey)
+	}
+
+	// 4. Let output be an empty string.
+	output := ""
+	// 5. Append input_key to output.
+	output += value
+	// 6. Return output.
+	return output, nil
+}
+
 /*
 # 4.1.2. Serializing a Dictionary
 
@@ -21,7 +260,7 @@ https://httpwg.org/specs/rfc8941.html#ser-dictionary
 // Given an ordered Dictionary as input_dictionary (each member having a member_key and a tuple value of (member_value, parameters)), return an ASCII string suitable for use in an HTTP field value.
 //
 // https://httpwg.org/specs/rfc8941.html#ser-dictionary
-func SerDictionary(inputDictionary Dictionary) (string, error) {
+func SerDictionary(inputDictionary legacyDictionary) (string, error) {
 	// 1. Let output be an empty string.
 	output := ""
 	// 2. For each member_key with a value of (member_value, parameters) in input_dictionary:
@@ -46,18 +285,18 @@ func SerDictionary(inputDictionary Dictionary) (string, error) {
 			}
 			output += parametersStr
 		} else {
-		// 3. Otherwise:
+			// 3. Otherwise:
 			// 1. Append "=" to output.
 			output += "="
 			// 2. If member_value is an array, append the result of running Serializing an Inner List (Section 4.1.1.1) with (member_value, parameters) to output.
-			if _, ok := memberValue.([]any); ok {
-				innerListStr, err := SerInnerList(memberValue, parameters)
+			if v, ok := memberValue.(legacyInnerList); ok {
+				innerListStr, err := SerInnerList(v, parameters)
 				if err != nil {
 					return "", err
 				}
 				output += innerListStr
 			} else {
-			// 3. Otherwise, append the result of running Serializing an Item (Section 4.1.3) with (member_value, parameters) to output.
+				// 3. Otherwise, append the result of running Serializing an Item (Section 4.1.3) with (member_value, parameters) to output.
 				itemStr, err := SerItem(memberValue, parameters)
 				if err != nil {
 					return "", err
@@ -118,6 +357,34 @@ https://httpwg.org/specs/rfc8941.html#ser-bare-item
 //
 // https://httpwg.org/specs/rfc8941.html#ser-bare-item
 func SerBareItem(inputItem any) (string, error) {
+	// The typed Integer, Decimal, String, ByteSeq, and Boolean (see typed.go)
+	// are unwrapped to the legacy any-shaped values the rest of this
+	// function type-switches on. Token is not unwrapped here: it is already
+	// the same type ParseToken/SerToken use, so the Token case below handles
+	// it directly.
+	switch v := inputItem.(type) {
+	case Integer:
+		return SerInteger(int64(v))
+	case Decimal:
+		return SerDecimal(float64(v))
+	case String:
+		return SerString(string(v))
+	case ByteSeq:
+		return SerByteSequence([]byte(v))
+	case Boolean:
+		return SerBoolean(bool(v))
+	case Date:
+		if !RFC9651 {
+			break
+		}
+		return SerDate(v)
+	case DisplayString:
+		if !RFC9651 {
+			break
+		}
+		return SerDisplayString(v)
+	}
+
 	// 1. If input_item is an Integer, return the result of running Serializing an Integer (Section 4.1.4) with input_item.
 	if _, ok := inputItem.(int64); ok {
 		return SerInteger(inputItem)
@@ -191,6 +458,13 @@ https://httpwg.org/specs/rfc8941.html#ser-decimal
 
 // Given a decimal number as input_decimal, return an ASCII string suitable for use in an HTTP field value.
 //
+// This works in a scaled int64 (thousandths) rather than float64 throughout:
+// extracting the integer and fractional parts straight from a float64 mixes
+// the fractional component's own decimal point back into the output (e.g.
+// formatting 0.5 as "0.5" and appending it after "1." yields "1.0.5" instead
+// of "1.5"), and it loses the trailing zeros the RFC requires. Rounding to
+// thousandths up front sidesteps both problems.
+//
 // https://httpwg.org/specs/rfc8941.html#ser-decimal
 func SerDecimal(inputDecimal any) (string, error) {
 	// 1. If input_decimal is not a decimal number, fail serialization.
@@ -200,28 +474,23 @@ func SerDecimal(inputDecimal any) (string, error) {
 	}
 
 	// 2. If input_decimal has more than three significant digits to the right of the decimal point, round it to three decimal places, rounding the final digit to the nearest value, or to the even value if it is equidistant.
-	value = math.RoundToEven(value * 1000) / 1000
+	scaled := int64(math.RoundToEven(value * 1000))
 
 	// 3. If input_decimal has more than 12 significant digits to the left of the decimal point after rounding, fail serialization.
-	if value > 999999999999 {
+	if scaled <= -1_000_000_000_000_000 || scaled >= 1_000_000_000_000_000 {
 		return "", fmt.Errorf("serialization failed: %#+v", inputDecimal)
 	}
 
 	// 4. Let output be an empty string.
 	output := ""
 	// 5. If input_decimal is less than (but not equal to) 0, append "-" to output.
-	if value < 0 {
+	if scaled < 0 {
 		output += "-"
+		scaled = -scaled
 	}
 	// 6. Append input_decimal's integer component represented in base 10 (using only decimal digits) to output; if it is zero, append "0".
-	integer, fractional := math.Modf(value)
-	integer = math.Abs(integer)
-	fractional = math.Abs(fractional)
-	if integer == 0 {
-		output += "0"
-	} else {
-		output += strconv.FormatInt(int64(integer), 10)
-	}
+	integer, fractional := scaled/1000, scaled%1000
+	output += strconv.FormatInt(integer, 10)
 	// 7. Append "." to output.
 	output += "."
 	// 8. If input_decimal's fractional component is zero, append "0" to output.
@@ -229,9 +498,9 @@ func SerDecimal(inputDecimal any) (string, error) {
 		output += "0"
 	} else {
 		// 9. Otherwise, append the significant digits of input_decimal's fractional component represented in base 10 (using only decimal digits) to output.
-		output += strings.TrimRight(strconv.FormatFloat(fractional, 'f', -1, 64), "0")
+		output += strings.TrimRight(fmt.Sprintf("%03d", fractional), "0")
 	}
-	
+
 	// 10. Return output.
 	return output, nil
 }
@@ -256,9 +525,9 @@ func SerString(inputString any) (string, error) {
 	}
 
 	// 2. If input_string contains characters in the range %x00-1f or %x7f-ff (i.e., not in VCHAR or SP), fail serialization.
-	for _, r := range value {
-		rint := int(r)
-		if (rint >= 0x00 && rint <= 0x1f) || (rint >= 0x7f && rint <= 0xff) {
+	for i := 0; i < len(value); i++ {
+		b := value[i]
+		if !(rfc5234.IsVCHAR(b) || rfc5234.IsSP(b)) {
 			return "", fmt.Errorf("serialization failed: %#+v", inputString)
 		}
 	}
@@ -299,11 +568,12 @@ func SerToken(inputToken Token) (string, error) {
 	}
 
 	// 2. If the first character of input_token is not ALPHA or "*", or the remaining portion contains a character not in tchar, ":", or "/", fail serialization.
-	if !(rfc5234.ALPHA.MatchString(value[:1]) || value[0] == '*') {
+	if !(rfc5234.IsALPHA(value[0]) || value[0] == '*') {
 		return "", fmt.Errorf("serialization failed: %#+v", inputToken)
 	}
-	for _, r := range value[1:] {
-		if !(rfc7230.TChar.MatchString(string(r)) || r == ':' || r == '/') {
+	for i := 1; i < len(value); i++ {
+		b := value[i]
+		if !(rfc7230.IsTChar(b) || b == ':' || b == '/') {
 			return "", fmt.Errorf("serialization failed: %#+v", inputToken)
 		}
 	}
@@ -0,0 +1,405 @@
+package rfc8941
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/barweiss/go-tuple"
+	"github.com/jcbhmr/go-fetch/rfc7230"
+	"golang.org/x/exp/utf8string"
+)
+
+// BareItem is one of the six bare item types defined by RFC 8941 Section 3.3:
+// Integer, Decimal, String, Token, ByteSeq, and Boolean. It is a sealed
+// interface: only the types in this file implement it.
+type BareItem interface {
+	bareItem()
+}
+
+// Integer is the Integer bare item type (Section 3.3.1).
+type Integer int64
+
+func (Integer) bareItem() {}
+
+// NewInteger validates value against the Integer range (Section 3.3.1), so
+// that a constructed Integer can never fail to serialize.
+func NewInteger(value int64) (Integer, error) {
+	if value < -999999999999999 || value > 999999999999999 {
+		return 0, fmt.Errorf("rfc8941: integer %d out of range", value)
+	}
+	return Integer(value), nil
+}
+
+// Decimal is the Decimal bare item type (Section 3.3.2).
+type Decimal float64
+
+func (Decimal) bareItem() {}
+
+// NewDecimal rounds value to three fractional digits, following the
+// rounding rule in Serializing a Decimal (Section 4.1.5), and validates that
+// the rounded value fits within 12 integer digits, so that a constructed
+// Decimal can never fail to serialize.
+func NewDecimal(value float64) (Decimal, error) {
+	rounded := math.RoundToEven(value*1000) / 1000
+	if rounded > 999999999999 || rounded < -999999999999 {
+		return 0, fmt.Errorf("rfc8941: decimal %v out of range", value)
+	}
+	return Decimal(rounded), nil
+}
+
+// String is the String bare item type (Section 3.3.3).
+type String string
+
+func (String) bareItem() {}
+
+// NewString validates value against the String charset (Section 4.1.6): it
+// must be ASCII and contain no characters outside VCHAR and SP.
+func NewString(value string) (String, error) {
+	if !utf8string.NewString(value).IsASCII() {
+		return "", fmt.Errorf("rfc8941: string %q is not ASCII", value)
+	}
+	for _, r := range value {
+		if (r >= 0x00 && r <= 0x1f) || (r >= 0x7f && r <= 0xff) {
+			return "", fmt.Errorf("rfc8941: string %q contains a disallowed character", value)
+		}
+	}
+	return String(value), nil
+}
+
+// Token is the Token bare item type (Section 3.3.4). It is also the return
+// type of ParseToken and the argument type of SerToken.
+type Token string
+
+func (Token) bareItem() {}
+
+// NewToken validates value against the Token charset (Section 4.1.7): it
+// must start with ALPHA or "*", and the rest must be tchar, ":", or "/".
+func NewToken(value string) (Token, error) {
+	if value == "" {
+		return "", fmt.Errorf("rfc8941: token must not be empty")
+	}
+	if !((value[0] >= 'A' && value[0] <= 'Z') || (value[0] >= 'a' && value[0] <= 'z') || value[0] == '*') {
+		return "", fmt.Errorf("rfc8941: token %q must start with ALPHA or \"*\"", value)
+	}
+	for _, r := range value[1:] {
+		if !(rfc7230.TChar.MatchString(string(r)) || r == ':' || r == '/') {
+			return "", fmt.Errorf("rfc8941: token %q contains a disallowed character", value)
+		}
+	}
+	return Token(value), nil
+}
+
+// ByteSeq is the Byte Sequence bare item type (Section 3.3.5). Any sequence
+// of bytes is valid.
+type ByteSeq []byte
+
+func (ByteSeq) bareItem() {}
+
+// NewByteSeq wraps value as a ByteSeq. It never fails: any byte sequence is
+// a valid Byte Sequence.
+func NewByteSeq(value []byte) ByteSeq {
+	return ByteSeq(value)
+}
+
+// Boolean is the Boolean bare item type (Section 3.3.6).
+type Boolean bool
+
+func (Boolean) bareItem() {}
+
+// NewBoolean wraps value as a Boolean. It never fails.
+func NewBoolean(value bool) Boolean {
+	return Boolean(value)
+}
+
+// toLegacyBareItem converts a BareItem to the any-shaped representation
+// that SerBareItem and the rest of the text-based codec in textparse.go and
+// textserialize.go operate on.
+func toLegacyBareItem(v BareItem) any {
+	switch v := v.(type) {
+	case Integer:
+		return int64(v)
+	case Decimal:
+		return float64(v)
+	case String:
+		return string(v)
+	case Token:
+		return v
+	case ByteSeq:
+		return []byte(v)
+	case Boolean:
+		return bool(v)
+	case Date:
+		return v
+	case DisplayString:
+		return v
+	default:
+		return v
+	}
+}
+
+// fromLegacyBareItem converts the any-shaped bare item value produced by
+// ParseBareItem (int64, float64, string, Token, []byte, or bool) into a
+// BareItem.
+func fromLegacyBareItem(v any) (BareItem, error) {
+	switch v := v.(type) {
+	case int64:
+		return Integer(v), nil
+	case float64:
+		return Decimal(v), nil
+	case string:
+		return String(v), nil
+	case Token:
+		return v, nil
+	case []byte:
+		return ByteSeq(v), nil
+	case bool:
+		return Boolean(v), nil
+	case Date:
+		return v, nil
+	case DisplayString:
+		return v, nil
+	default:
+		return nil, fmt.Errorf("rfc8941: %#v is not a bare item", v)
+	}
+}
+
+// fromLegacyMember converts a member_value/parameters pair, as found in a
+// legacyList or legacyDictionary, into a ListMember: an Item if
+// member_value is a bare item, or an InnerList if it is a legacyInnerList.
+func fromLegacyMember(memberValue any, params Parameters) (ListMember, error) {
+	if inner, ok := memberValue.(legacyInnerList); ok {
+		items := make([]Item, len(inner))
+		for i, it := range inner {
+			value, err := fromLegacyBareItem(it.V1)
+			if err != nil {
+				return nil, err
+			}
+			items[i] = Item{Value: value, Parameters: it.V2}
+		}
+		return InnerList{Items: items, Parameters: params}, nil
+	}
+	value, err := fromLegacyBareItem(memberValue)
+	if err != nil {
+		return nil, err
+	}
+	return Item{Value: value, Parameters: params}, nil
+}
+
+// ListFromLegacy converts the value TextParse returns for field_type "list"
+// into a typed List.
+func ListFromLegacy(v StructuredFieldValue) (List, error) {
+	legacy, ok := v.(legacyList)
+	if !ok {
+		return nil, fmt.Errorf("rfc8941: %#v is not a parsed list", v)
+	}
+	list := make(List, len(legacy))
+	for i, m := range legacy {
+		member, err := fromLegacyMember(m.V1, m.V2)
+		if err != nil {
+			return nil, err
+		}
+		list[i] = member
+	}
+	return list, nil
+}
+
+// DictionaryFromLegacy converts the value TextParse returns for field_type
+// "dictionary" into a typed Dictionary.
+func DictionaryFromLegacy(v StructuredFieldValue) (Dictionary, error) {
+	legacy, ok := v.(legacyDictionary)
+	if !ok {
+		return Dictionary{}, fmt.Errorf("rfc8941: %#v is not a parsed dictionary", v)
+	}
+	var dictionary Dictionary
+	for _, kv := range legacy {
+		member, err := fromLegacyMember(kv.V2.V1, kv.V2.V2)
+		if err != nil {
+			return Dictionary{}, err
+		}
+		dictionary.members = append(dictionary.members, dictMember{Key: kv.V1, Member: member})
+	}
+	return dictionary, nil
+}
+
+// ItemFromLegacy converts the value TextParse returns for field_type "item"
+// into a typed Item.
+func ItemFromLegacy(v StructuredFieldValue) (Item, error) {
+	legacy, ok := v.(tuple.T2[any, Parameters])
+	if !ok {
+		return Item{}, fmt.Errorf("rfc8941: %#v is not a parsed item", v)
+	}
+	value, err := fromLegacyBareItem(legacy.V1)
+	if err != nil {
+		return Item{}, err
+	}
+	return Item{Value: value, Parameters: legacy.V2}, nil
+}
+
+// ListMember is either an Item or an InnerList, the two shapes that a List
+// or Dictionary member can take (Section 3.1).
+type ListMember interface {
+	listMember()
+}
+
+// AsInnerList returns m as an InnerList if it is one, rather than an Item.
+func AsInnerList(m ListMember) (InnerList, bool) {
+	il, ok := m.(InnerList)
+	return il, ok
+}
+
+// Item pairs a BareItem with its Parameters (Section 3.3).
+type Item struct {
+	Value      BareItem
+	Parameters Parameters
+}
+
+func (Item) listMember() {}
+
+// AsInt64 returns it.Value as an int64 if it is an Integer.
+func (it Item) AsInt64() (int64, bool) {
+	v, ok := it.Value.(Integer)
+	return int64(v), ok
+}
+
+// AsFloat64 returns it.Value as a float64 if it is a Decimal.
+func (it Item) AsFloat64() (float64, bool) {
+	v, ok := it.Value.(Decimal)
+	return float64(v), ok
+}
+
+// AsString returns it.Value as a string if it is a String.
+func (it Item) AsString() (string, bool) {
+	v, ok := it.Value.(String)
+	return string(v), ok
+}
+
+// AsToken returns it.Value as a Token if it is one.
+func (it Item) AsToken() (Token, bool) {
+	v, ok := it.Value.(Token)
+	return v, ok
+}
+
+// AsBytes returns it.Value as a []byte if it is a ByteSeq.
+func (it Item) AsBytes() ([]byte, bool) {
+	v, ok := it.Value.(ByteSeq)
+	return []byte(v), ok
+}
+
+// AsBool returns it.Value as a bool if it is a Boolean.
+func (it Item) AsBool() (bool, bool) {
+	v, ok := it.Value.(Boolean)
+	return bool(v), ok
+}
+
+func (it Item) toLegacy() tuple.T2[any, Parameters] {
+	return tuple.New2[any, Parameters](toLegacyBareItem(it.Value), it.Parameters)
+}
+
+// InnerList is an ordered list of Items together with its own Parameters
+// (Section 3.1.1).
+type InnerList struct {
+	Items      []Item
+	Parameters Parameters
+}
+
+func (InnerList) listMember() {}
+
+func (il InnerList) toLegacy() legacyInnerList {
+	out := make(legacyInnerList, len(il.Items))
+	for i, it := range il.Items {
+		out[i] = it.toLegacy()
+	}
+	return out
+}
+
+// List is an ordered list of members (Section 3.1).
+type List []ListMember
+
+// Append adds an Item built from v and params to the end of the list.
+func (l *List) Append(v BareItem, params Parameters) {
+	*l = append(*l, Item{Value: v, Parameters: params})
+}
+
+// AppendInnerList adds an InnerList member to the end of the list.
+func (l *List) AppendInnerList(inner InnerList) {
+	*l = append(*l, inner)
+}
+
+func (l List) toLegacy() legacyList {
+	out := make(legacyList, len(l))
+	for i, m := range l {
+		switch m := m.(type) {
+		case Item:
+			out[i] = tuple.New2[ItemOrInnerList, Parameters](toLegacyBareItem(m.Value), m.Parameters)
+		case InnerList:
+			out[i] = tuple.New2[ItemOrInnerList, Parameters](m.toLegacy(), m.Parameters)
+		}
+	}
+	return out
+}
+
+// dictMember is one Dictionary entry: a key paired with its member.
+type dictMember struct {
+	Key    string
+	Member ListMember
+}
+
+// Dictionary is an ordered map of keys to members (Section 3.2).
+type Dictionary struct {
+	members []dictMember
+}
+
+// Set adds or replaces the member for key with an Item built from v and
+// params.
+func (d *Dictionary) Set(key string, v BareItem, params Parameters) {
+	d.setMember(key, Item{Value: v, Parameters: params})
+}
+
+// SetInnerList adds or replaces the member for key with an InnerList.
+func (d *Dictionary) SetInnerList(key string, inner InnerList) {
+	d.setMember(key, inner)
+}
+
+func (d *Dictionary) setMember(key string, member ListMember) {
+	for i, m := range d.members {
+		if m.Key == key {
+			d.members[i].Member = member
+			return
+		}
+	}
+	d.members = append(d.members, dictMember{Key: key, Member: member})
+}
+
+// Get returns the member stored for key, if any.
+func (d *Dictionary) Get(key string) (ListMember, bool) {
+	for _, m := range d.members {
+		if m.Key == key {
+			return m.Member, true
+		}
+	}
+	return nil, false
+}
+
+// Keys returns the Dictionary's keys in insertion order.
+func (d *Dictionary) Keys() []string {
+	keys := make([]string, len(d.members))
+	for i, m := range d.members {
+		keys[i] = m.Key
+	}
+	return keys
+}
+
+func (d Dictionary) toLegacy() legacyDictionary {
+	out := make(legacyDictionary, len(d.members))
+	for i, m := range d.members {
+		var value tuple.T2[ItemOrInnerList, Parameters]
+		switch mem := m.Member.(type) {
+		case Item:
+			value = tuple.New2[ItemOrInnerList, Parameters](toLegacyBareItem(mem.Value), mem.Parameters)
+		case InnerList:
+			value = tuple.New2[ItemOrInnerList, Parameters](mem.toLegacy(), mem.Parameters)
+		}
+		out[i] = tuple.New2(m.Key, value)
+	}
+	return out
+}
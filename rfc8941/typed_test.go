@@ -0,0 +1,51 @@
+package rfc8941
+
+import "testing"
+
+func TestItemAccessors(t *testing.T) {
+	tok, err := NewToken("gzip")
+	if err != nil {
+		t.Fatal(err)
+	}
+	it := Item{Value: tok}
+
+	if v, ok := it.AsToken(); !ok || v != tok {
+		t.Errorf("AsToken() = %q, %v, want %q, true", v, ok, tok)
+	}
+	if _, ok := it.AsInt64(); ok {
+		t.Error("AsInt64() ok = true for a Token item")
+	}
+	if _, ok := it.AsFloat64(); ok {
+		t.Error("AsFloat64() ok = true for a Token item")
+	}
+	if _, ok := it.AsString(); ok {
+		t.Error("AsString() ok = true for a Token item")
+	}
+	if _, ok := it.AsBytes(); ok {
+		t.Error("AsBytes() ok = true for a Token item")
+	}
+	if _, ok := it.AsBool(); ok {
+		t.Error("AsBool() ok = true for a Token item")
+	}
+
+	integer, err := NewInteger(42)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v, ok := (Item{Value: integer}).AsInt64(); !ok || v != 42 {
+		t.Errorf("AsInt64() = %d, %v, want 42, true", v, ok)
+	}
+}
+
+func TestAsInnerList(t *testing.T) {
+	inner := InnerList{Items: []Item{{Value: Boolean(true)}}}
+	var m ListMember = inner
+	if got, ok := AsInnerList(m); !ok || len(got.Items) != 1 {
+		t.Errorf("AsInnerList() = %#v, %v, want %#v, true", got, ok, inner)
+	}
+
+	var item ListMember = Item{Value: Boolean(true)}
+	if _, ok := AsInnerList(item); ok {
+		t.Error("AsInnerList() ok = true for an Item")
+	}
+}
@@ -0,0 +1,188 @@
+package rfc9421
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"fmt"
+	"math/big"
+)
+
+// Algorithm identifies a signing algorithm by the name it is registered
+// under in the HTTP Signature Algorithms registry (Section 6.2.2).
+type Algorithm string
+
+const (
+	AlgorithmEd25519         Algorithm = "ed25519"
+	AlgorithmECDSAP256SHA256 Algorithm = "ecdsa-p256-sha256"
+	AlgorithmHMACSHA256      Algorithm = "hmac-sha256"
+	AlgorithmRSAPSSSHA512    Algorithm = "rsa-pss-sha512"
+)
+
+// Signer produces a raw signature over a signature base string (Section
+// 2.5) for a specific key and Algorithm.
+type Signer interface {
+	Algorithm() Algorithm
+	Sign(base []byte) ([]byte, error)
+}
+
+// Verifier validates a raw signature over a signature base string.
+type Verifier interface {
+	Algorithm() Algorithm
+	Verify(base, signature []byte) error
+}
+
+type ed25519Signer struct{ key ed25519.PrivateKey }
+
+// NewEd25519Signer returns a Signer for the "ed25519" algorithm.
+func NewEd25519Signer(key ed25519.PrivateKey) Signer {
+	return ed25519Signer{key}
+}
+
+func (ed25519Signer) Algorithm() Algorithm { return AlgorithmEd25519 }
+
+func (s ed25519Signer) Sign(base []byte) ([]byte, error) {
+	return ed25519.Sign(s.key, base), nil
+}
+
+type ed25519Verifier struct{ key ed25519.PublicKey }
+
+// NewEd25519Verifier returns a Verifier for the "ed25519" algorithm.
+func NewEd25519Verifier(key ed25519.PublicKey) Verifier {
+	return ed25519Verifier{key}
+}
+
+func (ed25519Verifier) Algorithm() Algorithm { return AlgorithmEd25519 }
+
+func (v ed25519Verifier) Verify(base, signature []byte) error {
+	if !ed25519.Verify(v.key, base, signature) {
+		return fmt.Errorf("rfc9421: ed25519 signature verification failed")
+	}
+	return nil
+}
+
+type ecdsaP256SHA256Signer struct{ key *ecdsa.PrivateKey }
+
+// NewECDSAP256SHA256Signer returns a Signer for the "ecdsa-p256-sha256"
+// algorithm. The signature is the fixed-size r || s encoding (Section
+// 3.4.4 of [FIPS186-4], as used by JWS ES256), not ASN.1 DER.
+func NewECDSAP256SHA256Signer(key *ecdsa.PrivateKey) Signer {
+	return ecdsaP256SHA256Signer{key}
+}
+
+func (ecdsaP256SHA256Signer) Algorithm() Algorithm { return AlgorithmECDSAP256SHA256 }
+
+func (s ecdsaP256SHA256Signer) Sign(base []byte) ([]byte, error) {
+	digest := sha256.Sum256(base)
+	r, sVal, err := ecdsa.Sign(rand.Reader, s.key, digest[:])
+	if err != nil {
+		return nil, err
+	}
+	return append(leftPad(r.Bytes(), 32), leftPad(sVal.Bytes(), 32)...), nil
+}
+
+type ecdsaP256SHA256Verifier struct{ key *ecdsa.PublicKey }
+
+// NewECDSAP256SHA256Verifier returns a Verifier for the
+// "ecdsa-p256-sha256" algorithm.
+func NewECDSAP256SHA256Verifier(key *ecdsa.PublicKey) Verifier {
+	return ecdsaP256SHA256Verifier{key}
+}
+
+func (ecdsaP256SHA256Verifier) Algorithm() Algorithm { return AlgorithmECDSAP256SHA256 }
+
+func (v ecdsaP256SHA256Verifier) Verify(base, signature []byte) error {
+	if len(signature) != 64 {
+		return fmt.Errorf("rfc9421: ecdsa-p256-sha256 signature must be 64 bytes, got %d", len(signature))
+	}
+	r := new(big.Int).SetBytes(signature[:32])
+	s := new(big.Int).SetBytes(signature[32:])
+	digest := sha256.Sum256(base)
+	if !ecdsa.Verify(v.key, digest[:], r, s) {
+		return fmt.Errorf("rfc9421: ecdsa-p256-sha256 signature verification failed")
+	}
+	return nil
+}
+
+func leftPad(b []byte, size int) []byte {
+	if len(b) >= size {
+		return b[len(b)-size:]
+	}
+	out := make([]byte, size)
+	copy(out[size-len(b):], b)
+	return out
+}
+
+type hmacSHA256Signer struct{ key []byte }
+
+// NewHMACSHA256Signer returns a Signer for the "hmac-sha256" algorithm.
+func NewHMACSHA256Signer(key []byte) Signer {
+	return hmacSHA256Signer{key}
+}
+
+func (hmacSHA256Signer) Algorithm() Algorithm { return AlgorithmHMACSHA256 }
+
+func (s hmacSHA256Signer) Sign(base []byte) ([]byte, error) {
+	mac := hmac.New(sha256.New, s.key)
+	mac.Write(base)
+	return mac.Sum(nil), nil
+}
+
+type hmacSHA256Verifier struct{ key []byte }
+
+// NewHMACSHA256Verifier returns a Verifier for the "hmac-sha256"
+// algorithm.
+func NewHMACSHA256Verifier(key []byte) Verifier {
+	return hmacSHA256Verifier{key}
+}
+
+func (hmacSHA256Verifier) Algorithm() Algorithm { return AlgorithmHMACSHA256 }
+
+func (v hmacSHA256Verifier) Verify(base, signature []byte) error {
+	mac := hmac.New(sha256.New, v.key)
+	mac.Write(base)
+	if !hmac.Equal(mac.Sum(nil), signature) {
+		return fmt.Errorf("rfc9421: hmac-sha256 signature verification failed")
+	}
+	return nil
+}
+
+var rsaPSSSHA512Options = &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash, Hash: crypto.SHA512}
+
+type rsaPSSSHA512Signer struct{ key *rsa.PrivateKey }
+
+// NewRSAPSSSHA512Signer returns a Signer for the "rsa-pss-sha512"
+// algorithm.
+func NewRSAPSSSHA512Signer(key *rsa.PrivateKey) Signer {
+	return rsaPSSSHA512Signer{key}
+}
+
+func (rsaPSSSHA512Signer) Algorithm() Algorithm { return AlgorithmRSAPSSSHA512 }
+
+func (s rsaPSSSHA512Signer) Sign(base []byte) ([]byte, error) {
+	digest := sha512.Sum512(base)
+	return rsa.SignPSS(rand.Reader, s.key, crypto.SHA512, digest[:], rsaPSSSHA512Options)
+}
+
+type rsaPSSSHA512Verifier struct{ key *rsa.PublicKey }
+
+// NewRSAPSSSHA512Verifier returns a Verifier for the "rsa-pss-sha512"
+// algorithm.
+func NewRSAPSSSHA512Verifier(key *rsa.PublicKey) Verifier {
+	return rsaPSSSHA512Verifier{key}
+}
+
+func (rsaPSSSHA512Verifier) Algorithm() Algorithm { return AlgorithmRSAPSSSHA512 }
+
+func (v rsaPSSSHA512Verifier) Verify(base, signature []byte) error {
+	digest := sha512.Sum512(base)
+	if err := rsa.VerifyPSS(v.key, crypto.SHA512, digest[:], signature, rsaPSSSHA512Options); err != nil {
+		return fmt.Errorf("rfc9421: rsa-pss-sha512 signature verification failed: %w", err)
+	}
+	return nil
+}
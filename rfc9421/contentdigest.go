@@ -0,0 +1,17 @@
+package rfc9421
+
+import (
+	"crypto/sha256"
+
+	"github.com/jcbhmr/go-fetch/rfc8941"
+)
+
+// ContentDigestSHA256 returns the Content-Digest (RFC 9530) field value
+// covering body with a SHA-256 digest: the Structured Fields Dictionary
+// `sha-256=:<base64>:`.
+func ContentDigestSHA256(body []byte) (string, error) {
+	sum := sha256.Sum256(body)
+	return rfc8941.NewDictSerializer().
+		BareItem("sha-256", []byte(sum[:]), nil).
+		Finish()
+}
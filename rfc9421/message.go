@@ -0,0 +1,65 @@
+// Package rfc9421 implements HTTP Message Signatures (RFC 9421): building a
+// signature base string from a set of covered components, signing and
+// verifying it, and rendering/parsing the resulting Signature-Input and
+// Signature structured fields (using rfc8941).
+package rfc9421
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Message is the subset of an HTTP request rfc9421 needs in order to
+// derive component values: its method and target URL, for the derived
+// components (Section 2.2), and its header fields, for named-header
+// components (Section 2.1).
+type Message struct {
+	Method string
+	URL    *url.URL
+	Header http.Header
+}
+
+// ComponentValue returns the canonical value of the covered component
+// identifier (the bare identifiers this package supports: "@method",
+// "@target-uri", "@authority", "@scheme", "@path", "@query", or a header
+// field name). Component parameters (e.g. "sf" or "key") are not
+// supported.
+func (msg Message) ComponentValue(identifier string) (string, error) {
+	switch identifier {
+	case "@method":
+		return strings.ToUpper(msg.Method), nil
+	case "@target-uri":
+		return msg.URL.String(), nil
+	case "@authority":
+		return strings.ToLower(msg.URL.Host), nil
+	case "@scheme":
+		return strings.ToLower(msg.URL.Scheme), nil
+	case "@path":
+		if msg.URL.Path == "" {
+			return "/", nil
+		}
+		return msg.URL.Path, nil
+	case "@query":
+		if msg.URL.RawQuery == "" {
+			return "?", nil
+		}
+		return "?" + msg.URL.RawQuery, nil
+	}
+	if strings.HasPrefix(identifier, "@") {
+		return "", fmt.Errorf("rfc9421: unsupported derived component %q", identifier)
+	}
+
+	// Section 2.1: combine a field's values by stripping leading/trailing
+	// whitespace from each and joining them with ", ".
+	values := msg.Header.Values(identifier)
+	if len(values) == 0 {
+		return "", fmt.Errorf("rfc9421: covered component %q has no header field", identifier)
+	}
+	trimmed := make([]string, len(values))
+	for i, v := range values {
+		trimmed[i] = strings.TrimSpace(v)
+	}
+	return strings.Join(trimmed, ", "), nil
+}
@@ -0,0 +1,143 @@
+package rfc9421
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jcbhmr/go-fetch/rfc8941"
+)
+
+// algorithmOnlySigner lets Verify reuse SignOptions.signatureParams to
+// reconstruct the exact @signature-params text a signature's "alg"
+// parameter implies, without needing a real Signer (Verify never calls
+// Sign).
+type algorithmOnlySigner struct{ alg Algorithm }
+
+func (s algorithmOnlySigner) Algorithm() Algorithm { return s.alg }
+func (algorithmOnlySigner) Sign([]byte) ([]byte, error) {
+	panic("rfc9421: algorithmOnlySigner.Sign must not be called")
+}
+
+// Verify checks every signature in msg's Signature-Input and Signature
+// headers against body, looking up the Verifier for each by its "keyid"
+// signature parameter (falling back to the signature's label if there is
+// no "keyid") in verifiers. It returns the first error encountered,
+// including an unsigned message or a signature with no matching Verifier.
+func Verify(msg Message, body []byte, verifiers map[string]Verifier) error {
+	inputHeader := msg.Header.Get("Signature-Input")
+	sigHeader := msg.Header.Get("Signature")
+	if inputHeader == "" || sigHeader == "" {
+		return fmt.Errorf("rfc9421: message has no Signature-Input or Signature header")
+	}
+
+	inputDict, err := parseSignatureDictionary(inputHeader)
+	if err != nil {
+		return fmt.Errorf("rfc9421: parsing Signature-Input: %w", err)
+	}
+	sigDict, err := parseSignatureDictionary(sigHeader)
+	if err != nil {
+		return fmt.Errorf("rfc9421: parsing Signature: %w", err)
+	}
+
+	for _, label := range inputDict.Keys() {
+		inputMember, _ := inputDict.Get(label)
+		inner, ok := inputMember.(rfc8941.InnerList)
+		if !ok {
+			return fmt.Errorf("rfc9421: Signature-Input member %q is not an Inner List", label)
+		}
+
+		sigMember, ok := sigDict.Get(label)
+		if !ok {
+			return fmt.Errorf("rfc9421: no Signature for label %q", label)
+		}
+		sigItem, ok := sigMember.(rfc8941.Item)
+		if !ok {
+			return fmt.Errorf("rfc9421: Signature member %q is not an Item", label)
+		}
+		signature, ok := sigItem.Value.(rfc8941.ByteSeq)
+		if !ok {
+			return fmt.Errorf("rfc9421: Signature member %q is not a Byte Sequence", label)
+		}
+
+		covered := make([]string, len(inner.Items))
+		for i, item := range inner.Items {
+			id, ok := item.Value.(rfc8941.String)
+			if !ok {
+				return fmt.Errorf("rfc9421: Signature-Input member %q has a non-string component identifier", label)
+			}
+			covered[i] = string(id)
+		}
+
+		var created, expires *time.Time
+		var nonce, keyID string
+		var alg Algorithm
+		for _, p := range inner.Parameters {
+			switch v := p.V2.(type) {
+			case int64:
+				switch p.V1 {
+				case "created":
+					t := time.Unix(v, 0)
+					created = &t
+				case "expires":
+					t := time.Unix(v, 0)
+					expires = &t
+				}
+			case string:
+				switch p.V1 {
+				case "nonce":
+					nonce = v
+				case "alg":
+					alg = Algorithm(v)
+				case "keyid":
+					keyID = v
+				}
+			}
+		}
+
+		verifier, ok := verifiers[keyID]
+		if !ok {
+			verifier, ok = verifiers[label]
+		}
+		if !ok {
+			return fmt.Errorf("rfc9421: no Verifier for keyid %q (label %q)", keyID, label)
+		}
+		if alg != "" && alg != verifier.Algorithm() {
+			return fmt.Errorf("rfc9421: label %q: alg %q does not match Verifier algorithm %q", label, alg, verifier.Algorithm())
+		}
+
+		for _, id := range covered {
+			if id != "content-digest" {
+				continue
+			}
+			expected, err := ContentDigestSHA256(body)
+			if err != nil {
+				return fmt.Errorf("rfc9421: label %q: %w", label, err)
+			}
+			if msg.Header.Get("Content-Digest") != expected {
+				return fmt.Errorf("rfc9421: label %q: Content-Digest does not match body", label)
+			}
+		}
+
+		verifyOpts := SignOptions{Label: label, Covered: covered, KeyID: keyID, Created: created, Expires: expires, Nonce: nonce}
+		if alg != "" {
+			verifyOpts.Signer = algorithmOnlySigner{alg}
+		}
+
+		base, _, err := SignatureBase(msg, verifyOpts)
+		if err != nil {
+			return fmt.Errorf("rfc9421: label %q: %w", label, err)
+		}
+		if err := verifier.Verify(base, []byte(signature)); err != nil {
+			return fmt.Errorf("rfc9421: label %q: %w", label, err)
+		}
+	}
+	return nil
+}
+
+func parseSignatureDictionary(header string) (rfc8941.Dictionary, error) {
+	parsed, err := rfc8941.TextParse([]byte(header), "dictionary")
+	if err != nil {
+		return rfc8941.Dictionary{}, err
+	}
+	return rfc8941.DictionaryFromLegacy(parsed)
+}
@@ -0,0 +1,229 @@
+package fetch
+
+import (
+	"io"
+	"sync"
+	"time"
+)
+
+// defaultTeeHighWaterMark bounds how many bytes Tee buffers for a branch
+// before the pump blocks waiting for that branch's consumer to catch up.
+const defaultTeeHighWaterMark = 64 * 1024
+
+// ReadableStream is an incrementally-readable byte stream backing a Body,
+// analogous to the JS API's ReadableStream.
+type ReadableStream struct {
+	reader io.ReadCloser
+
+	mu        sync.Mutex
+	cancelled bool
+	reason    error
+
+	readDeadline  *deadlineTimer
+	writeDeadline *deadlineTimer
+}
+
+func newReadableStream(reader io.ReadCloser) *ReadableStream {
+	return &ReadableStream{
+		reader:        reader,
+		readDeadline:  newDeadlineTimer(),
+		writeDeadline: newDeadlineTimer(),
+	}
+}
+
+// SetReadDeadline arms a deadline for the stream's Read calls: once t
+// elapses, the stream is Cancelled with ErrDeadlineExceeded, so a Read
+// blocked on the underlying reader returns promptly once that reader
+// notices it was closed. A zero t clears the deadline without cancelling
+// in-flight work.
+func (s *ReadableStream) SetReadDeadline(t time.Time) {
+	s.readDeadline.Set(t, func() {
+		s.Cancel(ErrDeadlineExceeded)
+	})
+}
+
+// SetWriteDeadline arms a deadline for work that feeds this stream rather
+// than reads from it: Tee's pump goroutine blocks writing a chunk to a
+// branch whose consumer has fallen behind highWaterMark, and this bounds
+// that wait, failing both branches with ErrDeadlineExceeded. Unlike
+// SetReadDeadline, firing it does not Cancel the stream itself -- only Tee
+// branches spawned from it are affected, since the underlying reader may
+// still have other consumers. A zero t clears the deadline without
+// cancelling in-flight work.
+func (s *ReadableStream) SetWriteDeadline(t time.Time) {
+	s.writeDeadline.Set(t, nil)
+}
+
+// Read reads the next chunk into p, delivering the result asynchronously
+// like Body's other accessors.
+func (s *ReadableStream) Read(p []byte) <-chan Result[int] {
+	c := make(chan Result[int], 1)
+	go func() {
+		defer close(c)
+		s.mu.Lock()
+		if s.cancelled {
+			reason := s.reason
+			s.mu.Unlock()
+			c <- Result[int]{Err: reason}
+			return
+		}
+		s.mu.Unlock()
+
+		n, err := s.reader.Read(p)
+		if err != nil {
+			// A Read already in flight when Cancel closes the underlying
+			// reader surfaces whatever error that close produced (e.g. an
+			// os.ErrClosed-flavored error from the transport), not reason;
+			// substitute reason so a deadline firing mid-read still
+			// reports ErrDeadlineExceeded (or whatever Cancel was given)
+			// instead of that incidental error.
+			s.mu.Lock()
+			if s.cancelled {
+				err = s.reason
+			}
+			s.mu.Unlock()
+		}
+		c <- Result[int]{Value: n, Err: err}
+	}()
+	return c
+}
+
+// Cancel stops the stream: later Reads fail with reason (or io.EOF if
+// reason is nil), and the underlying reader is closed. It is safe to call
+// more than once.
+func (s *ReadableStream) Cancel(reason error) error {
+	s.mu.Lock()
+	if s.cancelled {
+		s.mu.Unlock()
+		return nil
+	}
+	s.cancelled = true
+	s.reason = reason
+	s.mu.Unlock()
+	s.readDeadline.Stop()
+	s.writeDeadline.Stop()
+	return s.reader.Close()
+}
+
+// Tee splits s into two independent ReadableStreams that each observe the
+// same bytes — for example, to cache a response while also streaming it to
+// a caller. highWaterMark bounds how many bytes are buffered for a branch
+// whose consumer falls behind (defaultTeeHighWaterMark if <= 0); until that
+// limit is hit, a slow branch does not stall the other.
+func (s *ReadableStream) Tee(highWaterMark int) (*ReadableStream, *ReadableStream) {
+	if highWaterMark <= 0 {
+		highWaterMark = defaultTeeHighWaterMark
+	}
+
+	a := newTeeRing(highWaterMark)
+	b := newTeeRing(highWaterMark)
+
+	done := make(chan struct{})
+	go func() {
+		select {
+		case <-s.writeDeadline.Channel():
+			a.close(ErrDeadlineExceeded)
+			b.close(ErrDeadlineExceeded)
+		case <-done:
+		}
+	}()
+
+	go func() {
+		defer close(done)
+		buf := make([]byte, 32*1024)
+		for {
+			n, err := s.reader.Read(buf)
+			if n > 0 {
+				chunk := append([]byte(nil), buf[:n]...)
+				a.push(chunk)
+				b.push(chunk)
+			}
+			if err != nil {
+				a.close(err)
+				b.close(err)
+				return
+			}
+		}
+	}()
+
+	return newReadableStream(a), newReadableStream(b)
+}
+
+// teeRing is an io.ReadCloser fed by Tee's pump goroutine through a bounded
+// queue of chunks, so each branch can be read at its own pace up to
+// highWaterMark buffered bytes.
+type teeRing struct {
+	mu            sync.Mutex
+	cond          *sync.Cond
+	chunks        [][]byte
+	pending       []byte
+	buffered      int
+	highWaterMark int
+	closed        bool
+	err           error
+}
+
+func newTeeRing(highWaterMark int) *teeRing {
+	r := &teeRing{highWaterMark: highWaterMark}
+	r.cond = sync.NewCond(&r.mu)
+	return r
+}
+
+func (r *teeRing) push(chunk []byte) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for r.buffered >= r.highWaterMark && !r.closed {
+		r.cond.Wait()
+	}
+	if r.closed {
+		return
+	}
+	r.chunks = append(r.chunks, chunk)
+	r.buffered += len(chunk)
+	r.cond.Broadcast()
+}
+
+func (r *teeRing) close(err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.closed {
+		return
+	}
+	r.closed = true
+	if err != io.EOF {
+		r.err = err
+	}
+	r.cond.Broadcast()
+}
+
+func (r *teeRing) Read(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for len(r.pending) == 0 && len(r.chunks) == 0 {
+		if r.closed {
+			if r.err != nil {
+				return 0, r.err
+			}
+			return 0, io.EOF
+		}
+		r.cond.Wait()
+	}
+	if len(r.pending) == 0 {
+		r.pending, r.chunks = r.chunks[0], r.chunks[1:]
+	}
+	n := copy(p, r.pending)
+	r.pending = r.pending[n:]
+	r.buffered -= n
+	r.cond.Broadcast()
+	return n, nil
+}
+
+// Close stops feeding this branch; it does not affect the other branch or
+// the original stream's underlying reader, which Tee's pump owns.
+func (r *teeRing) Close() error {
+	r.mu.Lock()
+	r.closed = true
+	r.cond.Broadcast()
+	r.mu.Unlock()
+	return nil
+}